@@ -0,0 +1,63 @@
+package errtypes
+
+// SuggestedAction returns a short hint for the client, how to react to the error, e.g. "check your input"
+// it can be overridden with WithAction. For errors without a hint it returns an empty string
+func SuggestedAction(err error) string {
+	for _, err := range publicChain(err) {
+		if a, ok := err.(withAction); ok {
+			return a.action
+		}
+	}
+	switch KindOf(err) {
+	case KindBadInput:
+		return "check your input"
+	case KindUnauthenticated:
+		return "authenticate"
+	case KindForbidden:
+		return "request access"
+	case KindNotFound:
+		return "check the identifier"
+	case KindPayloadTooLarge:
+		return "reduce the request size"
+	case KindConflict:
+		return "re-fetch and retry"
+	case KindTooManyRequests:
+		return "slow down and retry"
+	case KindServiceUnavailable, KindBadGateway, KindGatewayTimeout, KindTimeout:
+		return "retry later"
+	case KindInternal:
+		return "contact support"
+	default:
+		return ""
+	}
+}
+
+// WithAction overrides the hint returned by SuggestedAction for the error
+// the classification of the error is preserved. It returns nil for nil errors
+func WithAction(err error, action string) error {
+	if err == nil {
+		return nil
+	}
+	return withAction{err: err, action: action}
+}
+
+// withAction is the wrapper returned by WithAction
+type withAction struct {
+	err    error
+	action string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withAction) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withAction) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withAction) Unwrap() error {
+	return e.err
+}
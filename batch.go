@@ -0,0 +1,238 @@
+package errtypes
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DedupByCode keeps only the first error of each code, in the order of their first appearance
+// errors without a code are all kept
+func DedupByCode(errs []error) []error {
+	var res []error
+	seen := map[string]bool{}
+	for _, err := range errs {
+		code := Code(err)
+		if code != "" {
+			if seen[code] {
+				continue
+			}
+			seen[code] = true
+		}
+		res = append(res, err)
+	}
+	return res
+}
+
+// FirstClassified returns the first error, which matches any of the types of this package
+// if there is none, it returns the first non nil error, or nil
+func FirstClassified(errs ...error) error {
+	var first error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if IsClassified(err) {
+			return err
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// MostSevere returns the error, which determines the overall status, if several operations failed
+// server errors win over client errors and among them the highest status code wins. Among client errors
+// the lowest status code wins, so that a bad input error is preferred. Errors with a success status code,
+// e.g. the 207 of partially failed batch operations, lose against all others. If multiple errors have the same status code,
+// the first one wins. Nil errors are skipped
+func MostSevere(errs ...error) error {
+	var res error
+	var resStatus int
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if status := statusCode(err); res == nil || moreSevere(status, resStatus) {
+			res, resStatus = err, status
+		}
+	}
+	return res
+}
+
+// moreSevere checks, whether the status code a is more severe than b by the rules of MostSevere
+func moreSevere(a, b int) bool {
+	if (a < 400) != (b < 400) {
+		return a >= 400
+	}
+	if a < 500 && b < 500 {
+		return a < b
+	}
+	return a > b
+}
+
+// Combine returns an error, which combines the non nil errors, e.g. of parallel operations
+// the combined error has the kind of the error picked by MostSevere, e.g. bad input for a mix of bad input and conflict errors.
+// It returns nil, if there are no errors, and the error itself, if there is only one
+func Combine(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return &combinedError{errs: nonNil}
+}
+
+// BulkResult returns the overall HTTP status code of the errors of a bulk operation and the errors combined by Combine
+// the status code is the one of the most severe error, like for MostSevere. If all errors are nil, it returns 200 and nil
+func BulkResult(errs []error) (status int, err error) {
+	err = Combine(errs...)
+	if err == nil {
+		return http.StatusOK, nil
+	}
+	return HTTPStatusCode(err), err
+}
+
+// combinedError is the error returned by Combine
+type combinedError struct {
+	errs []error
+}
+
+// Error returns the messages of all combined errors, separated by semicolons
+func (e *combinedError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Errors returns the combined errors
+func (e *combinedError) Errors() []error {
+	return e.errs
+}
+
+// Unwrap returns the combined errors
+func (e *combinedError) Unwrap() []error {
+	return e.errs
+}
+
+// retryAfter returns the soonest Retry-After of the combined errors
+func (e *combinedError) retryAfter() (time.Duration, bool) {
+	var min time.Duration
+	var found bool
+	for _, err := range e.errs {
+		if d, ok := RetryAfter(err); ok && (!found || d < min) {
+			min, found = d, true
+		}
+	}
+	return min, found
+}
+
+// kind returns the kind of the most severe combined error
+func (e *combinedError) kind() Kind {
+	return KindOf(MostSevere(e.errs...))
+}
+
+// IsBadInput indicates, whether the most severe combined error is a bad input error
+func (e *combinedError) IsBadInput() bool {
+	return e.kind() == KindBadInput
+}
+
+// IsUnauthenticated indicates, whether the most severe combined error is an unauthenticated error
+func (e *combinedError) IsUnauthenticated() bool {
+	return e.kind() == KindUnauthenticated
+}
+
+// IsForbidden indicates, whether the most severe combined error is a forbidden error
+func (e *combinedError) IsForbidden() bool {
+	return e.kind() == KindForbidden
+}
+
+// IsNotFound indicates, whether the most severe combined error is a not found error
+func (e *combinedError) IsNotFound() bool {
+	return e.kind() == KindNotFound
+}
+
+// IsConflict indicates, whether the most severe combined error is a conflict error
+func (e *combinedError) IsConflict() bool {
+	return e.kind() == KindConflict
+}
+
+// IsClientClosedRequest indicates, whether the most severe combined error is a client closed request error
+func (e *combinedError) IsClientClosedRequest() bool {
+	return e.kind() == KindClientClosedRequest
+}
+
+// IsTooManyRequests indicates, whether the most severe combined error is a too many requests error
+func (e *combinedError) IsTooManyRequests() bool {
+	return e.kind() == KindTooManyRequests
+}
+
+// IsServiceUnavailable indicates, whether the most severe combined error is a service unavailable error
+func (e *combinedError) IsServiceUnavailable() bool {
+	return e.kind() == KindServiceUnavailable
+}
+
+// IsBadGateway indicates, whether the most severe combined error is a bad gateway error
+func (e *combinedError) IsBadGateway() bool {
+	return e.kind() == KindBadGateway
+}
+
+// IsGatewayTimeout indicates, whether the most severe combined error is a gateway timeout error
+func (e *combinedError) IsGatewayTimeout() bool {
+	return e.kind() == KindGatewayTimeout
+}
+
+// IsTimeout indicates, whether the most severe combined error is a timeout error
+func (e *combinedError) IsTimeout() bool {
+	return e.kind() == KindTimeout
+}
+
+// IsInternal indicates, whether the most severe combined error is an internal error
+func (e *combinedError) IsInternal() bool {
+	return e.kind() == KindInternal
+}
+
+// IsNotAcceptable indicates, whether the most severe combined error is a not acceptable error
+func (e *combinedError) IsNotAcceptable() bool {
+	return e.kind() == KindNotAcceptable
+}
+
+// IsUnsupportedMediaType indicates, whether the most severe combined error is an unsupported media type error
+func (e *combinedError) IsUnsupportedMediaType() bool {
+	return e.kind() == KindUnsupportedMediaType
+}
+
+// IsPayloadTooLarge indicates, whether the most severe combined error is a payload too large error
+func (e *combinedError) IsPayloadTooLarge() bool {
+	return e.kind() == KindPayloadTooLarge
+}
+
+// IsMultiStatus indicates, whether the most severe combined error is a multi status error
+func (e *combinedError) IsMultiStatus() bool {
+	return IsMultiStatus(MostSevere(e.errs...))
+}
+
+// HTTPStatus returns the status code of the most severe combined error
+func (e *combinedError) HTTPStatus() int {
+	return statusCode(MostSevere(e.errs...))
+}
+
+// IsRetryable indicates, whether all combined errors are retryable
+func (e *combinedError) IsRetryable() bool {
+	for _, err := range e.errs {
+		if !IsRetryable(err) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package errtypes
+
+import (
+	"testing"
+)
+
+func TestMostSevere(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []error
+		want int
+	}{
+		{"bad input wins over conflict", []error{NewConflict("conflict"), NewBadInput("bad input")}, 400},
+		{"unauthenticated wins over too many requests", []error{NewTooManyRequests("slow down"), NewUnauthenticated("login")}, 401},
+		{"server error wins over client error", []error{NewBadInput("bad input"), NewServiceUnavailable("down")}, 503},
+		{"highest server error wins", []error{NewBadGateway("upstream"), NewInternal("internal"), NewGatewayTimeout("timeout")}, 504},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatusCode(MostSevere(tt.errs...)); got != tt.want {
+				t.Errorf("HTTPStatusCode(MostSevere()) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMostSevereFirstWinsTies(t *testing.T) {
+	first, second := NewBadInput("first"), NewBadInput("second")
+	if got := MostSevere(nil, first, second); got != first {
+		t.Errorf("MostSevere() = %v, want %v", got, first)
+	}
+	if got := MostSevere(nil, nil); got != nil {
+		t.Errorf("MostSevere(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestMostSevereMultiStatus(t *testing.T) {
+	items := NewMultiStatus(map[string]error{"a": nil, "b": NewNotFound("b not found")})
+	if got := HTTPStatusCode(MostSevere(NewBadInput("bad input"), items)); got != 400 {
+		t.Errorf("HTTPStatusCode(MostSevere()) = %d, want 400", got)
+	}
+	if got := HTTPStatusCode(MostSevere(items, NewConflict("conflict"))); got != 409 {
+		t.Errorf("HTTPStatusCode(MostSevere()) = %d, want 409", got)
+	}
+}
+
+func TestNewMultiStatusSuccess(t *testing.T) {
+	if err := NewMultiStatus(map[string]error{"a": nil, "b": nil}); err != nil {
+		t.Errorf("NewMultiStatus() = %v, want nil", err)
+	}
+}
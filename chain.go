@@ -0,0 +1,117 @@
+package errtypes
+
+// MaxChainDepth limits how many errors of a chain are inspected, to protect against cyclic chains
+var MaxChainDepth = 100
+
+// RootMessage returns the message of the innermost error of the chain, e.g. the error of a database driver
+// it returns an empty string for nil errors
+func RootMessage(err error) string {
+	errs := chain(err)
+	if len(errs) == 0 {
+		return ""
+	}
+	return errs[len(errs)-1].Error()
+}
+
+// WrapSeparator separates the message of a wrapping error from the message of its cause, e.g. " -> " instead of ": "
+// it only affects the messages, not the classification or Unwrap. It's meant to be set during initialization
+var WrapSeparator = ": "
+
+// wrapMessage prefixes the message of the cause with s and the WrapSeparator, unless s is empty
+func wrapMessage(s string, cause error) string {
+	if s == "" {
+		return cause.Error()
+	}
+	return s + WrapSeparator + cause.Error()
+}
+
+// cause returns the innermost error of the chain by following Cause() like errors.Cause of github.com/pkg/errors,
+// but it stops after MaxChainDepth errors
+func cause(err error) error {
+	for i := 1; i < MaxChainDepth; i++ {
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			return err
+		}
+		next := c.Cause()
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return err
+}
+
+// chain returns the errors of the chain, starting with err itself, but at most MaxChainDepth errors
+func chain(err error) []error {
+	var errs []error
+	for ; err != nil && len(errs) < MaxChainDepth; err = unwrap(err) {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// publicChain returns the errors of the chain like chain, but it stops at errors, which hide their causes from clients,
+// e.g. the ones returned by MaskForbiddenAsNotFound. It's used for the metadata, which is part of the responses
+func publicChain(err error) []error {
+	errs := chain(err)
+	for i, err := range errs {
+		if nf, ok := err.(notFoundError); ok && nf.masked {
+			return errs[:i+1]
+		}
+	}
+	return errs
+}
+
+// unwrap returns the next error of the chain, or nil, if there is none.
+// It supports both, the Unwrap convention of the standard library and the Cause convention of github.com/pkg/errors
+func unwrap(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Cause() error }:
+		return e.Cause()
+	}
+	return nil
+}
+
+// Annotate attaches cause to the error for debugging, without changing its message, classification or metadata
+// Unwrap of the returned error returns both, so that errors.Is and errors.As of the standard library find the cause,
+// while the lookups of this package follow Cause() to err. It returns nil for nil errors and err unchanged for nil causes
+func Annotate(err error, cause error) error {
+	if err == nil || cause == nil {
+		return err
+	}
+	return annotatedError{err: err, cause: cause}
+}
+
+// AnnotatedCause returns the cause attached by the outermost Annotate of the chain, nil if there is none
+func AnnotatedCause(err error) error {
+	for _, err := range chain(err) {
+		if a, ok := err.(annotatedError); ok {
+			return a.cause
+		}
+	}
+	return nil
+}
+
+// annotatedError is the error returned by Annotate
+type annotatedError struct {
+	err   error
+	cause error
+}
+
+// Error returns the message of the annotated error
+func (e annotatedError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the annotated error
+func (e annotatedError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the annotated error and the cause attached by Annotate
+func (e annotatedError) Unwrap() []error {
+	return []error{e.err, e.cause}
+}
@@ -0,0 +1,107 @@
+package errtypes
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestAnnotateKeepsClassificationAndMetadata(t *testing.T) {
+	dbErr := WithStatus(WithCode(errors.New("sql: no rows"), "db_code"), 500)
+	err := Annotate(WithDocURL(WithCode(NewNotFound("user not found"), "user_missing"), "https://docs"), dbErr)
+
+	if got := err.Error(); got != "user not found" {
+		t.Errorf("Error() = %q, want %q", got, "user not found")
+	}
+	if got := KindOf(err); got != KindNotFound {
+		t.Errorf("KindOf() = %s, want %s", got, KindNotFound)
+	}
+	if got := OutermostKind(err); got != KindNotFound {
+		t.Errorf("OutermostKind() = %s, want %s", got, KindNotFound)
+	}
+	if got := HTTPStatusCode(err); got != 404 {
+		t.Errorf("HTTPStatusCode() = %d, want 404", got)
+	}
+	if got := Code(err); got != "user_missing" {
+		t.Errorf("Code() = %q, want %q", got, "user_missing")
+	}
+	if got := DocURL(err); got != "https://docs" {
+		t.Errorf("DocURL() = %q, want %q", got, "https://docs")
+	}
+	if got := AnnotatedCause(err); got != dbErr {
+		t.Errorf("AnnotatedCause() = %v, want %v", got, dbErr)
+	}
+}
+
+func TestAnnotateUnwrap(t *testing.T) {
+	err := Annotate(WithCode(NewNotFound("user not found"), "user_missing"), sql.ErrNoRows)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Error("errors.Is() doesn't find the attached cause")
+	}
+	var nf NotFound
+	if !errors.As(err, &nf) {
+		t.Error("errors.As() doesn't find the annotated error")
+	}
+	if got := Code(err); got != "user_missing" {
+		t.Errorf("Code() = %q, want %q", got, "user_missing")
+	}
+}
+
+func TestAnnotateOutermostKind(t *testing.T) {
+	err := Annotate(NewNotFound("not found"), NewBadInput("bad input"))
+	if got := OutermostKind(err); got != KindNotFound {
+		t.Errorf("OutermostKind() = %s, want %s", got, KindNotFound)
+	}
+}
+
+func TestAnnotateNil(t *testing.T) {
+	if err := Annotate(nil, errors.New("cause")); err != nil {
+		t.Errorf("Annotate(nil, cause) = %v, want nil", err)
+	}
+	orig := NewNotFound("not found")
+	if err := Annotate(orig, nil); err != orig {
+		t.Errorf("Annotate(err, nil) = %v, want err unchanged", err)
+	}
+	if got := AnnotatedCause(orig); got != nil {
+		t.Errorf("AnnotatedCause() = %v, want nil", got)
+	}
+}
+
+// cyclicError is a wrapper, which wraps itself
+type cyclicError struct{}
+
+func (e *cyclicError) Error() string { return "cyclic" }
+func (e *cyclicError) Cause() error  { return e }
+func (e *cyclicError) Unwrap() error { return e }
+
+func TestCyclicChain(t *testing.T) {
+	err := &cyclicError{}
+	if got := cause(err); got != err {
+		t.Errorf("cause() = %v, want the cyclic error", got)
+	}
+	if got := len(chain(err)); got != MaxChainDepth {
+		t.Errorf("len(chain()) = %d, want %d", got, MaxChainDepth)
+	}
+	if _, ok := statusOverride(err); ok {
+		t.Error("statusOverride() found an override")
+	}
+	if got := HTTPStatusCode(err); got != UnknownStatus {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, UnknownStatus)
+	}
+}
+
+func TestCyclicChainWithoutDepth(t *testing.T) {
+	defer func(depth int) { MaxChainDepth = depth }(MaxChainDepth)
+	MaxChainDepth = 0
+
+	err := &cyclicError{}
+	if got := cause(err); got != err {
+		t.Errorf("cause() = %v, want the cyclic error", got)
+	}
+	if got := len(chain(err)); got != 0 {
+		t.Errorf("len(chain()) = %d, want 0", got)
+	}
+	if _, ok := statusOverride(WithStatus(err, 418)); ok {
+		t.Error("statusOverride() found an override")
+	}
+}
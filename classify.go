@@ -0,0 +1,49 @@
+package errtypes
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ClassifyByMessage classifies an unclassified error by its message, as a last resort for errors of third party libraries
+// the keys of the rules are matched as substrings or, if they are valid, as regular expressions in alphabetical order.
+// The first match reclassifies the error with the kind of the rule, keeping its message. Classified errors are returned unchanged
+func ClassifyByMessage(err error, rules map[string]Kind) error {
+	if err == nil || IsClassified(err) {
+		return err
+	}
+	patterns := make([]string, 0, len(rules))
+	for p := range rules {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+	msg := err.Error()
+	for _, p := range patterns {
+		if matches(msg, p) {
+			return Reclassify(err, rules[p], "")
+		}
+	}
+	return err
+}
+
+// ClassifyFunc returns the kind of the error determined by fn, e.g. to classify an error of a third party library inline
+// if fn doesn't know the error, it falls back to KindOf. It returns KindUnknown for nil errors
+func ClassifyFunc(err error, fn func(error) (Kind, bool)) Kind {
+	if err == nil {
+		return KindUnknown
+	}
+	if k, ok := fn(err); ok {
+		return k
+	}
+	return KindOf(err)
+}
+
+// matches checks, whether the message contains the pattern or matches it as regular expression
+func matches(msg, pattern string) bool {
+	if strings.Contains(msg, pattern) {
+		return true
+	}
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString(msg)
+}
@@ -0,0 +1,30 @@
+package errtypes
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Equal checks, whether both errors have the same kind, message, code and fields
+// it ignores the internals of the errors, e.g. for assertions in tests
+func Equal(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return KindOf(a) == KindOf(b) &&
+		a.Error() == b.Error() &&
+		Code(a) == Code(b) &&
+		reflect.DeepEqual(Fields(a), Fields(b))
+}
+
+// Bare returns a new error with the same kind and message as err, but without any metadata, e.g. fields, stack, code or tags
+// for deduplication or compact logging. It returns nil for nil errors
+func Bare(err error) error {
+	if err == nil {
+		return nil
+	}
+	if k := KindOf(err); k != KindUnknown {
+		return New(k, err.Error())
+	}
+	return errors.New(err.Error())
+}
@@ -0,0 +1,36 @@
+package errtypes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestBare(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"classified", WithTags(WithCode(WithField(NewNotFound("user not found"), "id", 42), "user_missing"), "team")},
+		{"unknown", WithStack(pkgerrors.New("unknown"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bare := Bare(tt.err)
+			if bare.Error() != tt.err.Error() || KindOf(bare) != KindOf(tt.err) {
+				t.Errorf("Bare() = %v (%s), want %v (%s)", bare, KindOf(bare), tt.err, KindOf(tt.err))
+			}
+			if Code(bare) != "" || len(Fields(bare)) != 0 || len(Tags(bare)) != 0 {
+				t.Errorf("Bare() kept metadata: code %q, fields %v, tags %v", Code(bare), Fields(bare), Tags(bare))
+			}
+			if s := fmt.Sprintf("%+v", bare); strings.Contains(s, "\n") {
+				t.Errorf("Bare() kept a stack: %s", s)
+			}
+		})
+	}
+	if Bare(nil) != nil {
+		t.Error("Bare(nil) isn't nil")
+	}
+}
@@ -0,0 +1,24 @@
+package errtypes
+
+// MergeStrategy tells the client how to resolve a conflict
+type MergeStrategy string
+
+const (
+	// MergeRetry is used, when the client should re-fetch the resource and retry
+	MergeRetry MergeStrategy = "retry"
+	// MergeOverwrite is used, when the client may overwrite the conflicting resource
+	MergeOverwrite MergeStrategy = "overwrite"
+	// MergeManual is used, when the conflict has to be resolved by the user
+	MergeManual MergeStrategy = "manual"
+)
+
+// ConflictStrategy returns the merge strategy of a conflict error, or an empty strategy
+func ConflictStrategy(err error) MergeStrategy {
+	if !IsConflict(err) {
+		return ""
+	}
+	if s, ok := cause(err).(interface{ Strategy() MergeStrategy }); ok {
+		return s.Strategy()
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package errtypes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// FromContext returns a client closed request error for context.Canceled, wrapping the original error
+// other errors are returned unchanged
+func FromContext(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return WrapClientClosedRequest(err, "request canceled")
+	}
+	return err
+}
+
+// traceIDExtractor is set by SetTraceIDExtractor
+var traceIDExtractor func(ctx context.Context) (string, bool)
+
+// SetTraceIDExtractor sets the function used by NewFromContext to read the trace ID of the context
+func SetTraceIDExtractor(fn func(ctx context.Context) (string, bool)) {
+	traceIDExtractor = fn
+}
+
+// NewFromContext returns an error of the kind with the message, annotated with the trace ID of the context as request ID
+// if no extractor is set or the context has no trace ID, it's the same as New
+func NewFromContext(ctx context.Context, kind Kind, s string) error {
+	err := New(kind, s)
+	if traceIDExtractor == nil {
+		return err
+	}
+	if id, ok := traceIDExtractor(ctx); ok {
+		return WithRequestID(err, id)
+	}
+	return err
+}
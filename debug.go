@@ -0,0 +1,55 @@
+package errtypes
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// debugLayer is the JSON representation of a single error of a chain
+type debugLayer struct {
+	Message        string                 `json:"message"`
+	Type           string                 `json:"type"`
+	Kind           string                 `json:"kind,omitempty"`
+	Code           string                 `json:"code,omitempty"`
+	Tags           []string               `json:"tags,omitempty"`
+	Fields         map[string]interface{} `json:"fields,omitempty"`
+	UpstreamStatus int                    `json:"upstream_status,omitempty"`
+	Caller         string                 `json:"caller,omitempty"`
+	Annotation     string                 `json:"annotation,omitempty"`
+}
+
+// DebugJSON serializes the whole chain of the error, from the outermost to the innermost error.
+// It's meant for internal tooling and must never be exposed to clients
+func DebugJSON(err error) []byte {
+	layers := []debugLayer{}
+	for _, err := range chain(err) {
+		l := debugLayer{
+			Message: err.Error(),
+			Type:    fmt.Sprintf("%T", err),
+		}
+		if k := layerKind(err); k != KindUnknown {
+			l.Kind = k.String()
+		}
+		if c, ok := err.(interface{ Code() string }); ok {
+			l.Code = c.Code()
+		}
+		if u, ok := err.(interface{ UpstreamStatus() int }); ok {
+			l.UpstreamStatus = u.UpstreamStatus()
+		}
+		if c, ok := err.(callerError); ok {
+			l.Caller = c.caller
+		}
+		if a, ok := err.(annotatedError); ok {
+			l.Annotation = a.cause.Error()
+		}
+		if t, ok := err.(withTags); ok {
+			l.Tags = t.tags
+		}
+		if f, ok := err.(interface{ Fields() map[string]interface{} }); ok {
+			l.Fields = f.Fields()
+		}
+		layers = append(layers, l)
+	}
+	b, _ := json.Marshal(layers)
+	return b
+}
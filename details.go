@@ -0,0 +1,101 @@
+package errtypes
+
+import (
+	"time"
+)
+
+// Details aggregates the metadata of an error, which is otherwise only accessible via the unexported implementations
+type Details struct {
+	Kind   Kind
+	Status int
+	Code   string
+	// Reason is the reason of forbidden and bad input errors
+	Reason Reason
+	// Field is the bad field of bad input errors
+	Field string
+	// ExistingID is the ID of the existing resource of conflict errors
+	ExistingID string
+	// Hint and Strategy tell the client how to resolve conflict errors
+	Hint     string
+	Strategy MergeStrategy
+	// Attempts is the number of attempts of errors created with NewRetryExhausted
+	Attempts int
+	Fields   map[string]interface{}
+}
+
+// ErrorDetails returns the metadata of the error, it returns false for nil errors
+func ErrorDetails(err error) (Details, bool) {
+	if err == nil {
+		return Details{}, false
+	}
+	d := Details{
+		Kind:       KindOf(err),
+		Status:     statusCode(err),
+		Code:       Code(err),
+		Field:      BadInputField(err),
+		ExistingID: ConflictExistingID(err),
+		Hint:       ConflictHint(err),
+		Strategy:   ConflictStrategy(err),
+		Fields:     Fields(err),
+	}
+	if r, ok := cause(err).(interface{ Reason() Reason }); ok {
+		d.Reason = r.Reason()
+	}
+	d.Attempts, _ = RetryAttempts(err)
+	return d, true
+}
+
+// RetryAttempts returns the number of attempts of a service unavailable error created with NewRetryExhausted
+func RetryAttempts(err error) (int, bool) {
+	a, ok := cause(err).(interface{ Attempts() int })
+	if !ok || a.Attempts() == 0 {
+		return 0, false
+	}
+	return a.Attempts(), true
+}
+
+// BadInputField returns the name of the bad field of a bad input error, or an empty string
+func BadInputField(err error) string {
+	if f, ok := cause(err).(interface{ Field() string }); ok && IsBadInput(err) {
+		return f.Field()
+	}
+	return ""
+}
+
+// ConflictHint returns the hint of a conflict error created with NewConflictWithHint, or an empty string
+func ConflictHint(err error) string {
+	if h, ok := cause(err).(interface{ Hint() string }); ok && IsConflict(err) {
+		return h.Hint()
+	}
+	return ""
+}
+
+// ConflictExistingID returns the ID of the existing resource of a conflict error created with NewConflictExisting, or an empty string
+func ConflictExistingID(err error) string {
+	if e, ok := cause(err).(interface{ ExistingID() string }); ok && IsConflict(err) {
+		return e.ExistingID()
+	}
+	return ""
+}
+
+// TimeoutOperation returns the operation and duration of a timeout error created with TimeoutFor
+func TimeoutOperation(err error) (string, time.Duration, bool) {
+	t, ok := cause(err).(interface {
+		Operation() string
+		Duration() time.Duration
+	})
+	if !ok || t.Operation() == "" {
+		return "", 0, false
+	}
+	return t.Operation(), t.Duration(), true
+}
+
+// SupportedMediaTypes returns the supported media types of an error created with NegotiationError or ContentTypeError
+func SupportedMediaTypes(err error) []string {
+	for _, err := range publicChain(err) {
+		if s, ok := err.(interface{ Supported() []string }); ok {
+			return s.Supported()
+		}
+	}
+	return nil
+}
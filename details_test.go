@@ -0,0 +1,18 @@
+package errtypes
+
+import (
+	"testing"
+)
+
+func TestRetryAttempts(t *testing.T) {
+	err := WithCode(NewRetryExhausted(3, NewTimeout("timeout")), "upstream")
+	if got, ok := RetryAttempts(err); !ok || got != 3 {
+		t.Errorf("RetryAttempts() = %d, %t, want 3, true", got, ok)
+	}
+	if d, _ := ErrorDetails(err); d.Attempts != 3 {
+		t.Errorf("ErrorDetails().Attempts = %d, want 3", d.Attempts)
+	}
+	if _, ok := RetryAttempts(NewServiceUnavailable("maintenance")); ok {
+		t.Error("RetryAttempts() = true for an error without attempts")
+	}
+}
@@ -2,8 +2,8 @@ package errtypes
 
 import (
 	"fmt"
-
-	"github.com/pkg/errors"
+	"net/http"
+	"time"
 )
 
 // BadInput is used for errors, which are caused by a missing or wrong input parameter.
@@ -36,9 +36,70 @@ type Conflict interface {
 	IsConflict() bool
 }
 
+// ClientClosedRequest is used for errors, which are caused by the client closing the request, e.g. by cancelling it
+// The corresponding HTTP status code is 499
+type ClientClosedRequest interface {
+	IsClientClosedRequest() bool
+}
+
+// TooManyRequests is used for errors, which are caused by the client exceeding a rate limit
+// The corresponding HTTP status code is 429
+type TooManyRequests interface {
+	IsTooManyRequests() bool
+}
+
+// ServiceUnavailable is used for errors, which are caused by the service being temporarily unavailable, e.g. because it's overloaded
+// The corresponding HTTP status code is 503
+type ServiceUnavailable interface {
+	IsServiceUnavailable() bool
+}
+
+// BadGateway is used for errors, which are caused by an invalid response of an upstream service
+// The corresponding HTTP status code is 502
+type BadGateway interface {
+	IsBadGateway() bool
+}
+
+// GatewayTimeout is used for errors, which are caused by an upstream service not responding in time
+// The corresponding HTTP status code is 504
+type GatewayTimeout interface {
+	IsGatewayTimeout() bool
+}
+
+// Timeout is used for errors, which are caused by an operation not finishing in time
+// The corresponding HTTP status code is 504
+type Timeout interface {
+	IsTimeout() bool
+}
+
+// Internal is used for errors, which are caused by an unexpected failure of the server, e.g. a bug
+// The corresponding HTTP status code is 500
+type Internal interface {
+	IsInternal() bool
+}
+
+// NotAcceptable is used for errors, which are caused by a client accepting no media type supported by the server
+// The corresponding HTTP status code is 406
+type NotAcceptable interface {
+	IsNotAcceptable() bool
+}
+
+// UnsupportedMediaType is used for errors, which are caused by a request body with a media type not supported by the server
+// The corresponding HTTP status code is 415
+type UnsupportedMediaType interface {
+	IsUnsupportedMediaType() bool
+}
+
+// PayloadTooLarge is used for errors, which are caused by a request body, which exceeds the allowed size
+// The corresponding HTTP status code is 413
+type PayloadTooLarge interface {
+	IsPayloadTooLarge() bool
+}
+
 // IsBadInput checks, whether this error is caused by a missing or wrong input parameter, or not
+// it returns false for nil errors
 func IsBadInput(err error) bool {
-	bi, ok := errors.Cause(err).(BadInput)
+	bi, ok := cause(err).(BadInput)
 	return ok && bi.IsBadInput()
 }
 
@@ -51,9 +112,69 @@ func NewBadInputf(s string, i ...interface{}) error {
 	return badInputError{s: fmt.Sprintf(s, i...)}
 }
 
+// WrapBadInput returns an error, which indicates that it's caused by a missing or wrong input parameter, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapBadInput(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return badInputError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// NewBadInputMissing returns a bad input error for a required field, which is missing
+func NewBadInputMissing(field string) error {
+	return badInputError{s: fmt.Sprintf("missing value for field '%s'", field), field: field, reason: ReasonMissing}
+}
+
+// NewBadInputInvalid returns a bad input error for a field with a malformed value
+func NewBadInputInvalid(field string) error {
+	return badInputError{s: fmt.Sprintf("invalid value for field '%s'", field), field: field, reason: ReasonInvalid}
+}
+
+// NewBadInputOutOfRange returns a bad input error for a field with a value, which is out of the allowed range
+func NewBadInputOutOfRange(field string) error {
+	return badInputError{s: fmt.Sprintf("value out of range for field '%s'", field), field: field, reason: ReasonOutOfRange}
+}
+
+// NewBadInputValue returns a bad input error for a field with an invalid value
+// the value isn't part of the message, but it's available via Value and Fields after being passed through the redactor
+func NewBadInputValue(field string, value interface{}) error {
+	return badInputError{
+		s:        fmt.Sprintf("invalid value for field '%s'", field),
+		field:    field,
+		reason:   ReasonInvalid,
+		value:    redactor(field, value),
+		hasValue: true,
+	}
+}
+
+// redactor is set by SetRedactor
+var redactor = func(field string, value interface{}) interface{} {
+	return value
+}
+
+// SetRedactor sets the function, which is applied to the values of NewBadInputValue before they are stored
+// e.g. to mask passwords. By default the values are stored unchanged
+func SetRedactor(fn func(field string, value interface{}) interface{}) {
+	redactor = fn
+}
+
+// BadInputValue returns the redacted value of a bad input error created with NewBadInputValue
+func BadInputValue(err error) (interface{}, bool) {
+	if v, ok := cause(err).(interface{ Value() (interface{}, bool) }); ok {
+		return v.Value()
+	}
+	return nil, false
+}
+
 // badInputError is the standard implementation of the BadInput
 type badInputError struct {
-	s string
+	s        string
+	field    string
+	reason   Reason
+	value    interface{}
+	hasValue bool
+	cause    error
 }
 
 // Error returns the string representation of this error
@@ -61,14 +182,55 @@ func (e badInputError) Error() string {
 	return e.s
 }
 
+// Unwrap returns the error, which has been classified by this error, if any
+func (e badInputError) Unwrap() error {
+	return e.cause
+}
+
 // IsBadInput indicates, whether this error is caused by a missing or wrong input parameter, or not
 func (e badInputError) IsBadInput() bool {
 	return true
 }
 
+// Temporary indicates, that this error isn't temporary
+func (e badInputError) Temporary() bool {
+	return false
+}
+
+// Reason returns why the input is bad, ReasonInvalid by default
+func (e badInputError) Reason() Reason {
+	if e.reason == "" {
+		return ReasonInvalid
+	}
+	return e.reason
+}
+
+// Field returns the name of the bad field, or an empty string
+func (e badInputError) Field() string {
+	return e.field
+}
+
+// Value returns the redacted value of the bad field, if it has been recorded
+func (e badInputError) Value() (interface{}, bool) {
+	return e.value, e.hasValue
+}
+
+// Fields returns the bad field and its redacted value, if they have been recorded
+func (e badInputError) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	if e.field != "" {
+		fields["field"] = e.field
+	}
+	if e.hasValue {
+		fields["value"] = e.value
+	}
+	return fields
+}
+
 // IsUnauthenticated checks, whether this error is caused by a missing authentication or not
+// it returns false for nil errors
 func IsUnauthenticated(err error) bool {
-	bi, ok := errors.Cause(err).(Unauthenticated)
+	bi, ok := cause(err).(Unauthenticated)
 	return ok && bi.IsUnauthenticated()
 }
 
@@ -83,9 +245,38 @@ func NewUnauthenticatedf(s string, args ...interface{}) error {
 	return unauthenticatedError{s: fmt.Sprintf(s, args...)}
 }
 
+// WrapUnauthenticated returns an error, which indicates that it's caused by missing authentication, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapUnauthenticated(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return unauthenticatedError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// WrapUnauthenticatedExpired returns an unauthenticated error, which is caused by expired credentials, e.g. a token, wrapping the cause
+// it returns nil for nil causes
+func WrapUnauthenticatedExpired(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return unauthenticatedError{s: wrapMessage(s, cause), reason: ReasonExpired, cause: cause}
+}
+
+// WrapUnauthenticatedInvalid returns an unauthenticated error, which is caused by malformed or forged credentials, wrapping the cause
+// it returns nil for nil causes
+func WrapUnauthenticatedInvalid(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return unauthenticatedError{s: wrapMessage(s, cause), reason: ReasonInvalid, cause: cause}
+}
+
 // unauthenticatedError is the standard implementation of the Unauthenticated
 type unauthenticatedError struct {
-	s string
+	s      string
+	reason Reason
+	cause  error
 }
 
 // Error returns the string representation of this error
@@ -93,14 +284,30 @@ func (e unauthenticatedError) Error() string {
 	return e.s
 }
 
+// Unwrap returns the error, which has been classified by this error, if any
+func (e unauthenticatedError) Unwrap() error {
+	return e.cause
+}
+
 // Unauthenticated indicates if this error is caused by missing authentication
 func (e unauthenticatedError) IsUnauthenticated() bool {
 	return true
 }
 
+// Temporary indicates, that this error isn't temporary
+func (e unauthenticatedError) Temporary() bool {
+	return false
+}
+
+// Reason returns why the authentication failed, an empty reason if it's unknown
+func (e unauthenticatedError) Reason() Reason {
+	return e.reason
+}
+
 // IsForbidden checks, whether this error is caused by insufficient permissions, or not
+// it returns false for nil errors
 func IsForbidden(err error) bool {
-	bi, ok := errors.Cause(err).(Forbidden)
+	bi, ok := cause(err).(Forbidden)
 	return ok && bi.IsForbidden()
 }
 
@@ -114,9 +321,30 @@ func NewForbiddenf(s string, i ...interface{}) error {
 	return forbiddenError{s: fmt.Sprintf(s, i...)}
 }
 
+// NewForbiddenPlanLimit returns a forbidden error, which is caused by a limit of the plan instead of missing permissions
+func NewForbiddenPlanLimit(s string) error {
+	return forbiddenError{s: s, reason: ReasonPlanLimit}
+}
+
+// NewForbiddenPlanLimitf returns a forbidden error, which is caused by a limit of the plan - supports sprintf
+func NewForbiddenPlanLimitf(s string, i ...interface{}) error {
+	return forbiddenError{s: fmt.Sprintf(s, i...), reason: ReasonPlanLimit}
+}
+
+// WrapForbidden returns an error, which indicates that it's caused by insufficient permissions, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapForbidden(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return forbiddenError{s: wrapMessage(s, cause), cause: cause}
+}
+
 // forbiddenError is the standard implementation of the Forbidden
 type forbiddenError struct {
-	s string
+	s      string
+	reason Reason
+	cause  error
 }
 
 // Error returns the string representation of this error
@@ -124,14 +352,42 @@ func (e forbiddenError) Error() string {
 	return e.s
 }
 
+// Unwrap returns the error, which has been classified by this error, if any
+func (e forbiddenError) Unwrap() error {
+	return e.cause
+}
+
 // Forbidden indicates if this error is caused by insufficient permissions
 func (e forbiddenError) IsForbidden() bool {
 	return true
 }
 
+// Temporary indicates, that this error isn't temporary
+func (e forbiddenError) Temporary() bool {
+	return false
+}
+
+// Reason returns why the access is forbidden, ReasonPermission by default
+func (e forbiddenError) Reason() Reason {
+	if e.reason == "" {
+		return ReasonPermission
+	}
+	return e.reason
+}
+
+// AuthError returns an unauthenticated error, if the caller isn't authenticated, and a forbidden error otherwise
+// so that unauthenticated callers never get a forbidden error
+func AuthError(authenticated bool, s string) error {
+	if !authenticated {
+		return NewUnauthenticated(s)
+	}
+	return NewForbidden(s)
+}
+
 // IsNotFound checks, whether this error is caused by a missing resource
+// it returns false for nil errors
 func IsNotFound(err error) bool {
-	bi, ok := errors.Cause(err).(NotFound)
+	bi, ok := cause(err).(NotFound)
 	return ok && bi.IsNotFound()
 }
 
@@ -145,9 +401,20 @@ func NewNotFoundf(s string, i ...interface{}) error {
 	return notFoundError{s: fmt.Sprintf(s, i...)}
 }
 
+// WrapNotFound returns an error, which indicates that it's caused by a missing resource, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapNotFound(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return notFoundError{s: wrapMessage(s, cause), cause: cause}
+}
+
 // notFoundError is the standard implementation of the NotFound
 type notFoundError struct {
-	s string
+	s      string
+	masked bool
+	cause  error
 }
 
 // Error returns the string representation of this error
@@ -155,14 +422,35 @@ func (e notFoundError) Error() string {
 	return e.s
 }
 
+// Unwrap returns the error, which has been classified by this error, if any
+func (e notFoundError) Unwrap() error {
+	return e.cause
+}
+
 // NotFound indicates if this error is caused by a missing resource
 func (e notFoundError) IsNotFound() bool {
 	return true
 }
 
+// Temporary indicates, that this error isn't temporary
+func (e notFoundError) Temporary() bool {
+	return false
+}
+
+// MaskForbiddenAsNotFound turns a forbidden error into a not found error with the neutral message "Not Found",
+// to avoid leaking the existence of resources the caller isn't allowed to access. The metadata of the forbidden error,
+// e.g. its code and action, isn't part of the responses. It stays reachable via Unwrap. Other errors are returned unchanged
+func MaskForbiddenAsNotFound(err error) error {
+	if !IsForbidden(err) {
+		return err
+	}
+	return notFoundError{s: http.StatusText(http.StatusNotFound), masked: true, cause: err}
+}
+
 // IsConflict checks, whether this error is caused by a conflicting resource
+// it returns false for nil errors
 func IsConflict(err error) bool {
-	v, ok := errors.Cause(err).(Conflict)
+	v, ok := cause(err).(Conflict)
 	return ok && v.IsConflict()
 }
 
@@ -176,9 +464,39 @@ func NewConflictf(s string, i ...interface{}) error {
 	return conflictError{s: fmt.Sprintf(s, i...)}
 }
 
+// NewConflictWithHint returns a conflict error, which carries a hint for the client how to resolve it,
+// e.g. to re-fetch the resource after a concurrent edit
+func NewConflictWithHint(s, hint string) error {
+	return conflictError{s: s, hint: hint}
+}
+
+// NewConflictWithStrategy returns a conflict error, which tells the client how to resolve it
+func NewConflictWithStrategy(s string, strategy MergeStrategy) error {
+	return conflictError{s: s, strategy: strategy}
+}
+
+// WrapConflict returns an error, which indicates that it's caused by a conflicting resource, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapConflict(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return conflictError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// NewConflictExisting returns a conflict error for a resource, which already exists with the ID
+// so that the client can adopt the existing resource
+func NewConflictExisting(id string) error {
+	return conflictError{s: fmt.Sprintf("resource with id '%s' already exists", id), existingID: id}
+}
+
 // conflictError is the standard implementation of the Conflict interface
 type conflictError struct {
-	s string
+	s          string
+	hint       string
+	strategy   MergeStrategy
+	existingID string
+	cause      error
 }
 
 // Error returns the string representation of this error
@@ -186,28 +504,761 @@ func (e conflictError) Error() string {
 	return e.s
 }
 
+// Unwrap returns the error, which has been classified by this error, if any
+func (e conflictError) Unwrap() error {
+	return e.cause
+}
+
 // conflictError indicates if this error is caused by a missing resource
 func (e conflictError) IsConflict() bool {
 	return true
 }
 
+// Temporary indicates, that this error isn't temporary
+func (e conflictError) Temporary() bool {
+	return false
+}
+
+// Hint returns the hint for the client how to resolve the conflict, or an empty string
+func (e conflictError) Hint() string {
+	return e.hint
+}
+
+// Strategy returns how the client should resolve the conflict, or an empty strategy
+func (e conflictError) Strategy() MergeStrategy {
+	return e.strategy
+}
+
+// ExistingID returns the ID of the already existing resource, or an empty string
+func (e conflictError) ExistingID() string {
+	return e.existingID
+}
+
+// IsClientClosedRequest checks, whether this error is caused by a request, which has been closed by the client
+// it returns false for nil errors
+func IsClientClosedRequest(err error) bool {
+	v, ok := cause(err).(ClientClosedRequest)
+	return ok && v.IsClientClosedRequest()
+}
+
+// NewClientClosedRequest returns an error, which indicates that it's caused by a request, which has been closed by the client
+func NewClientClosedRequest(s string) error {
+	return clientClosedRequestError{s: s}
+}
+
+// NewClientClosedRequestf returns an error, which indicates that it's caused by a request, which has been closed by the client - supports sprintf
+func NewClientClosedRequestf(s string, i ...interface{}) error {
+	return clientClosedRequestError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapClientClosedRequest returns an error, which indicates that it's caused by a request, which has been closed by the client, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapClientClosedRequest(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return clientClosedRequestError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// clientClosedRequestError is the standard implementation of the ClientClosedRequest interface
+type clientClosedRequestError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e clientClosedRequestError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e clientClosedRequestError) Unwrap() error {
+	return e.cause
+}
+
+// IsClientClosedRequest indicates if this error is caused by a request, which has been closed by the client
+func (e clientClosedRequestError) IsClientClosedRequest() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e clientClosedRequestError) Temporary() bool {
+	return false
+}
+
+// IsTooManyRequests checks, whether this error is caused by too many requests of the client
+// it returns false for nil errors
+func IsTooManyRequests(err error) bool {
+	v, ok := cause(err).(TooManyRequests)
+	return ok && v.IsTooManyRequests()
+}
+
+// NewTooManyRequests returns an error, which indicates that it's caused by too many requests of the client
+func NewTooManyRequests(s string) error {
+	return tooManyRequestsError{s: s}
+}
+
+// NewTooManyRequestsf returns an error, which indicates that it's caused by too many requests of the client - supports sprintf
+func NewTooManyRequestsf(s string, i ...interface{}) error {
+	return tooManyRequestsError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapTooManyRequests returns an error, which indicates that it's caused by too many requests of the client, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapTooManyRequests(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return tooManyRequestsError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// tooManyRequestsError is the standard implementation of the TooManyRequests interface
+type tooManyRequestsError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e tooManyRequestsError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e tooManyRequestsError) Unwrap() error {
+	return e.cause
+}
+
+// IsTooManyRequests indicates if this error is caused by too many requests of the client
+func (e tooManyRequestsError) IsTooManyRequests() bool {
+	return true
+}
+
+// IsRetryable indicates, that the request can be retried later
+func (e tooManyRequestsError) IsRetryable() bool {
+	return true
+}
+
+// Temporary indicates, that this error is temporary
+func (e tooManyRequestsError) Temporary() bool {
+	return true
+}
+
+// IsServiceUnavailable checks, whether this error is caused by a temporarily unavailable service
+// it returns false for nil errors
+func IsServiceUnavailable(err error) bool {
+	v, ok := cause(err).(ServiceUnavailable)
+	return ok && v.IsServiceUnavailable()
+}
+
+// NewServiceUnavailable returns an error, which indicates that it's caused by a temporarily unavailable service
+func NewServiceUnavailable(s string) error {
+	return serviceUnavailableError{s: s}
+}
+
+// NewServiceUnavailablef returns an error, which indicates that it's caused by a temporarily unavailable service - supports sprintf
+func NewServiceUnavailablef(s string, i ...interface{}) error {
+	return serviceUnavailableError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapServiceUnavailable returns an error, which indicates that it's caused by a temporarily unavailable service, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapServiceUnavailable(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return serviceUnavailableError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// NewRetryExhausted returns a service unavailable error, which indicates that a retry loop gave up after the attempts, wrapping the last error
+// e.g. "gave up after 3 attempts: connection refused"
+func NewRetryExhausted(attempts int, last error) error {
+	s := fmt.Sprintf("gave up after %d attempts", attempts)
+	if last != nil {
+		s = wrapMessage(s, last)
+	}
+	return serviceUnavailableError{s: s, attempts: attempts, cause: last}
+}
+
+// serviceUnavailableError is the standard implementation of the ServiceUnavailable interface
+type serviceUnavailableError struct {
+	s        string
+	attempts int
+	cause    error
+}
+
+// Error returns the string representation of this error
+func (e serviceUnavailableError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e serviceUnavailableError) Unwrap() error {
+	return e.cause
+}
+
+// IsServiceUnavailable indicates if this error is caused by a temporarily unavailable service
+func (e serviceUnavailableError) IsServiceUnavailable() bool {
+	return true
+}
+
+// IsRetryable indicates, that the request can be retried later
+func (e serviceUnavailableError) IsRetryable() bool {
+	return true
+}
+
+// Temporary indicates, that this error is temporary
+func (e serviceUnavailableError) Temporary() bool {
+	return true
+}
+
+// Attempts returns the number of attempts of a retry loop, which gave up, 0 if the error hasn't been created by NewRetryExhausted
+func (e serviceUnavailableError) Attempts() int {
+	return e.attempts
+}
+
+// IsBadGateway checks, whether this error is caused by an invalid response of an upstream service
+// it returns false for nil errors
+func IsBadGateway(err error) bool {
+	v, ok := cause(err).(BadGateway)
+	return ok && v.IsBadGateway()
+}
+
+// NewBadGateway returns an error, which indicates that it's caused by an invalid response of an upstream service
+func NewBadGateway(s string) error {
+	return badGatewayError{s: s}
+}
+
+// NewBadGatewayf returns an error, which indicates that it's caused by an invalid response of an upstream service - supports sprintf
+func NewBadGatewayf(s string, i ...interface{}) error {
+	return badGatewayError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapBadGateway returns an error, which indicates that it's caused by an invalid response of an upstream service, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapBadGateway(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return badGatewayError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// NewBadGatewayFromUpstream returns a bad gateway error, which records the status code received from the upstream service
+func NewBadGatewayFromUpstream(upstreamStatus int, s string) error {
+	return badGatewayError{s: s, upstreamStatus: upstreamStatus}
+}
+
+// badGatewayError is the standard implementation of the BadGateway interface
+type badGatewayError struct {
+	s              string
+	upstreamStatus int
+	cause          error
+}
+
+// Error returns the string representation of this error
+func (e badGatewayError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e badGatewayError) Unwrap() error {
+	return e.cause
+}
+
+// IsBadGateway indicates if this error is caused by an invalid response of an upstream service
+func (e badGatewayError) IsBadGateway() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e badGatewayError) Temporary() bool {
+	return false
+}
+
+// UpstreamStatus returns the status code received from the upstream service, or 0 if it's unknown
+func (e badGatewayError) UpstreamStatus() int {
+	return e.upstreamStatus
+}
+
+// IsGatewayTimeout checks, whether this error is caused by an upstream service, which didn't respond in time
+// it returns false for nil errors
+func IsGatewayTimeout(err error) bool {
+	v, ok := cause(err).(GatewayTimeout)
+	return ok && v.IsGatewayTimeout()
+}
+
+// NewGatewayTimeout returns an error, which indicates that it's caused by an upstream service, which didn't respond in time
+func NewGatewayTimeout(s string) error {
+	return gatewayTimeoutError{s: s}
+}
+
+// NewGatewayTimeoutf returns an error, which indicates that it's caused by an upstream service, which didn't respond in time - supports sprintf
+func NewGatewayTimeoutf(s string, i ...interface{}) error {
+	return gatewayTimeoutError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapGatewayTimeout returns an error, which indicates that it's caused by an upstream service, which didn't respond in time, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapGatewayTimeout(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return gatewayTimeoutError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// gatewayTimeoutError is the standard implementation of the GatewayTimeout interface
+type gatewayTimeoutError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e gatewayTimeoutError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e gatewayTimeoutError) Unwrap() error {
+	return e.cause
+}
+
+// IsGatewayTimeout indicates if this error is caused by an upstream service, which didn't respond in time
+func (e gatewayTimeoutError) IsGatewayTimeout() bool {
+	return true
+}
+
+// IsRetryable indicates, that the request can be retried later
+func (e gatewayTimeoutError) IsRetryable() bool {
+	return true
+}
+
+// Timeout indicates, that this error is a timeout - it implements net.Error
+func (e gatewayTimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary indicates, that this error is temporary - it implements net.Error
+func (e gatewayTimeoutError) Temporary() bool {
+	return true
+}
+
+// IsTimeout checks, whether this error is caused by an operation, which didn't finish in time
+// it returns false for nil errors
+func IsTimeout(err error) bool {
+	v, ok := cause(err).(Timeout)
+	return ok && v.IsTimeout()
+}
+
+// NewTimeout returns an error, which indicates that it's caused by an operation, which didn't finish in time
+func NewTimeout(s string) error {
+	return timeoutError{s: s}
+}
+
+// NewTimeoutf returns an error, which indicates that it's caused by an operation, which didn't finish in time - supports sprintf
+func NewTimeoutf(s string, i ...interface{}) error {
+	return timeoutError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapTimeout returns an error, which indicates that it's caused by an operation, which didn't finish in time, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapTimeout(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return timeoutError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// TimeoutFor returns a timeout error for the operation, e.g. "database query timed out after 2s"
+func TimeoutFor(operation string, d time.Duration) error {
+	return timeoutError{s: fmt.Sprintf("%s timed out after %s", operation, d), operation: operation, duration: d}
+}
+
+// timeoutError is the standard implementation of the Timeout interface
+type timeoutError struct {
+	s         string
+	operation string
+	duration  time.Duration
+	cause     error
+}
+
+// Error returns the string representation of this error
+func (e timeoutError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e timeoutError) Unwrap() error {
+	return e.cause
+}
+
+// IsTimeout indicates if this error is caused by an operation, which didn't finish in time
+func (e timeoutError) IsTimeout() bool {
+	return true
+}
+
+// IsRetryable indicates, that the request can be retried later
+func (e timeoutError) IsRetryable() bool {
+	return true
+}
+
+// Timeout indicates, that this error is a timeout - it implements net.Error
+func (e timeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary indicates, that this error is temporary - it implements net.Error
+func (e timeoutError) Temporary() bool {
+	return true
+}
+
+// Operation returns the name of the operation, which timed out, or an empty string
+func (e timeoutError) Operation() string {
+	return e.operation
+}
+
+// Duration returns the duration after which the operation timed out, or 0
+func (e timeoutError) Duration() time.Duration {
+	return e.duration
+}
+
+// IsInternal checks, whether this error is caused by an unexpected failure of the server
+// it returns false for nil errors
+func IsInternal(err error) bool {
+	v, ok := cause(err).(Internal)
+	return ok && v.IsInternal()
+}
+
+// NewInternal returns an error, which indicates that it's caused by an unexpected failure of the server
+func NewInternal(s string) error {
+	return internalError{s: s}
+}
+
+// NewInternalf returns an error, which indicates that it's caused by an unexpected failure of the server - supports sprintf
+func NewInternalf(s string, i ...interface{}) error {
+	return internalError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapInternal returns an error, which indicates that it's caused by an unexpected failure of the server, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapInternal(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return internalError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// internalError is the standard implementation of the Internal interface
+type internalError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e internalError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e internalError) Unwrap() error {
+	return e.cause
+}
+
+// IsInternal indicates if this error is caused by an unexpected failure of the server
+func (e internalError) IsInternal() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e internalError) Temporary() bool {
+	return false
+}
+
+// CustomStatus can be implemented by errors, which determine their HTTP status code themselves
+// it takes precedence over the types of this package
+type CustomStatus interface {
+	HTTPStatus() int
+}
+
+// IsNotAcceptable checks, whether this error is caused by a requested media type, which isn't supported
+// it returns false for nil errors
+func IsNotAcceptable(err error) bool {
+	v, ok := cause(err).(NotAcceptable)
+	return ok && v.IsNotAcceptable()
+}
+
+// NewNotAcceptable returns an error, which indicates that it's caused by a requested media type, which isn't supported
+func NewNotAcceptable(s string) error {
+	return notAcceptableError{s: s}
+}
+
+// NewNotAcceptablef returns an error, which indicates that it's caused by a requested media type, which isn't supported - supports sprintf
+func NewNotAcceptablef(s string, i ...interface{}) error {
+	return notAcceptableError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapNotAcceptable returns an error, which indicates that it's caused by a requested media type, which isn't supported, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapNotAcceptable(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return notAcceptableError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// notAcceptableError is the standard implementation of the NotAcceptable interface
+type notAcceptableError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e notAcceptableError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e notAcceptableError) Unwrap() error {
+	return e.cause
+}
+
+// IsNotAcceptable indicates if this error is caused by a requested media type, which isn't supported
+func (e notAcceptableError) IsNotAcceptable() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e notAcceptableError) Temporary() bool {
+	return false
+}
+
+// IsUnsupportedMediaType checks, whether this error is caused by a media type of the request, which isn't supported
+// it returns false for nil errors
+func IsUnsupportedMediaType(err error) bool {
+	v, ok := cause(err).(UnsupportedMediaType)
+	return ok && v.IsUnsupportedMediaType()
+}
+
+// NewUnsupportedMediaType returns an error, which indicates that it's caused by a media type of the request, which isn't supported
+func NewUnsupportedMediaType(s string) error {
+	return unsupportedMediaTypeError{s: s}
+}
+
+// NewUnsupportedMediaTypef returns an error, which indicates that it's caused by a media type of the request, which isn't supported - supports sprintf
+func NewUnsupportedMediaTypef(s string, i ...interface{}) error {
+	return unsupportedMediaTypeError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapUnsupportedMediaType returns an error, which indicates that it's caused by a media type of the request, which isn't supported, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapUnsupportedMediaType(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return unsupportedMediaTypeError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// unsupportedMediaTypeError is the standard implementation of the UnsupportedMediaType interface
+type unsupportedMediaTypeError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e unsupportedMediaTypeError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e unsupportedMediaTypeError) Unwrap() error {
+	return e.cause
+}
+
+// IsUnsupportedMediaType indicates if this error is caused by a media type of the request, which isn't supported
+func (e unsupportedMediaTypeError) IsUnsupportedMediaType() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e unsupportedMediaTypeError) Temporary() bool {
+	return false
+}
+
+// IsPayloadTooLarge checks, whether this error is caused by a request body, which exceeds the allowed size
+// it returns false for nil errors
+func IsPayloadTooLarge(err error) bool {
+	v, ok := cause(err).(PayloadTooLarge)
+	return ok && v.IsPayloadTooLarge()
+}
+
+// NewPayloadTooLarge returns an error, which indicates that it's caused by a request body, which exceeds the allowed size
+func NewPayloadTooLarge(s string) error {
+	return payloadTooLargeError{s: s}
+}
+
+// NewPayloadTooLargef returns an error, which indicates that it's caused by a request body, which exceeds the allowed size - supports sprintf
+func NewPayloadTooLargef(s string, i ...interface{}) error {
+	return payloadTooLargeError{s: fmt.Sprintf(s, i...)}
+}
+
+// WrapPayloadTooLarge returns an error, which indicates that it's caused by a request body, which exceeds the allowed size, wrapping the cause
+// the message is prefixed to the message of the cause, an empty message keeps the message of the cause. It returns nil for nil causes
+func WrapPayloadTooLarge(cause error, s string) error {
+	if cause == nil {
+		return nil
+	}
+	return payloadTooLargeError{s: wrapMessage(s, cause), cause: cause}
+}
+
+// payloadTooLargeError is the standard implementation of the PayloadTooLarge interface
+type payloadTooLargeError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e payloadTooLargeError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been classified by this error, if any
+func (e payloadTooLargeError) Unwrap() error {
+	return e.cause
+}
+
+// IsPayloadTooLarge indicates if this error is caused by a request body, which exceeds the allowed size
+func (e payloadTooLargeError) IsPayloadTooLarge() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e payloadTooLargeError) Temporary() bool {
+	return false
+}
+
+// UnknownStatus is the HTTP status code used for errors, which don't match any of the types of this package
+var UnknownStatus = 500
+
+// StrictUnknown makes HTTPStatusCode report errors, which fall back to UnknownStatus, e.g. in CI to ensure all errors are classified
+// they are passed to UnknownHook, or HTTPStatusCode panics, if there is no hook. Only the status lookups of responses report them,
+// helpers, which just classify errors, e.g. ShouldReport, MostSevere or Attributes, don't. It must stay disabled in production
+var StrictUnknown bool
+
+// UnknownHook is called by HTTPStatusCode with unclassified errors, if StrictUnknown is enabled
+var UnknownHook func(err error)
+
 // HTTPStatusCode determines the status code by the error type
 // it panics for non nil values, because it can't guarantee to pick the right success code
+// The first of these sources, which applies to the error, determines the status code:
+// an override of WithStatus, the HTTPStatus method of CustomStatus, the types of this package,
+// the matchers registered with RegisterStatus and finally UnknownStatus
 func HTTPStatusCode(err error) int {
+	code, ok := lookupStatus(err)
+	if !ok && StrictUnknown {
+		if UnknownHook == nil {
+			panic("unclassified error: " + err.Error())
+		}
+		UnknownHook(err)
+	}
+	return code
+}
+
+// statusCode returns the status code like HTTPStatusCode, but it never reports unknown errors
+// it's used by the helpers, which just classify errors
+func statusCode(err error) int {
+	code, _ := lookupStatus(err)
+	return code
+}
+
+// lookupStatus returns the status code by the sources of HTTPStatusCode, false if it falls back to UnknownStatus
+func lookupStatus(err error) (int, bool) {
 	if err == nil {
 		panic("called with nil error")
 	}
-	if IsBadInput(err) {
-		return 400
-	} else if IsUnauthenticated(err) {
-		return 401
-	} else if IsForbidden(err) {
-		return 403
-	} else if IsNotFound(err) {
-		return 404
-	} else if IsConflict(err) {
-		return 409
-	} else {
-		return 500
+	if code, ok := statusOverride(err); ok {
+		return code, true
+	}
+	c := cause(err)
+	if v, ok := c.(CustomStatus); ok {
+		return v.HTTPStatus(), true
+	}
+	if code, ok := typeStatus(c); ok {
+		return code, true
+	}
+	if code, ok := registeredStatus(err); ok {
+		return code, true
+	}
+	return UnknownStatus, false
+}
+
+// StatusCodeOr returns the status code of the error like HTTPStatusCode, but the fallback for nil errors instead of panicking
+// e.g. StatusCodeOr(err, http.StatusOK)
+func StatusCodeOr(err error, fallback int) int {
+	if err == nil {
+		return fallback
+	}
+	return HTTPStatusCode(err)
+}
+
+// typeStatus returns the status code corresponding to the type of the innermost error, if it matches any of the types of this package
+func typeStatus(c error) (int, bool) {
+	switch layerKind(c) {
+	case KindBadInput:
+		return 400, true
+	case KindUnauthenticated:
+		return 401, true
+	case KindForbidden:
+		return 403, true
+	case KindNotFound:
+		return 404, true
+	case KindConflict:
+		return 409, true
+	case KindClientClosedRequest:
+		return 499, true
+	case KindTooManyRequests:
+		return 429, true
+	case KindServiceUnavailable:
+		return 503, true
+	case KindBadGateway:
+		return 502, true
+	case KindGatewayTimeout, KindTimeout:
+		return 504, true
+	case KindInternal:
+		return 500, true
+	case KindNotAcceptable:
+		return 406, true
+	case KindUnsupportedMediaType:
+		return 415, true
+	case KindPayloadTooLarge:
+		return 413, true
+	}
+	if v, ok := c.(MultiStatus); ok && v.IsMultiStatus() {
+		return 207, true
+	}
+	return 0, false
+}
+
+// ReasonPhrase returns the HTTP reason phrase of the status code of the error, e.g. "Not Found"
+// like HTTPStatusCode it panics for nil values
+func ReasonPhrase(err error) string {
+	return statusText(statusCode(err))
+}
+
+// statusText returns the reason phrase of the status code, for server errors without one, e.g. 599, the one of 500
+// so that the messages of server errors are never empty
+func statusText(code int) string {
+	if s := http.StatusText(code); s != "" || code < 500 {
+		return s
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+// FromRecovered turns a value returned by recover() into an internal error
+// errors are wrapped, other values are formatted with %v. It returns nil for nil values
+func FromRecovered(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+	if err, ok := r.(error); ok {
+		return WrapInternal(err, "panic")
 	}
+	return NewInternalf("panic: %v", r)
 }
@@ -0,0 +1,175 @@
+package errtypes
+
+import (
+	"testing"
+)
+
+func TestIsNil(t *testing.T) {
+	tests := map[string]func(error) bool{
+		"IsBadInput":             IsBadInput,
+		"IsUnauthenticated":      IsUnauthenticated,
+		"IsForbidden":            IsForbidden,
+		"IsNotFound":             IsNotFound,
+		"IsConflict":             IsConflict,
+		"IsClientClosedRequest":  IsClientClosedRequest,
+		"IsTooManyRequests":      IsTooManyRequests,
+		"IsServiceUnavailable":   IsServiceUnavailable,
+		"IsBadGateway":           IsBadGateway,
+		"IsGatewayTimeout":       IsGatewayTimeout,
+		"IsTimeout":              IsTimeout,
+		"IsInternal":             IsInternal,
+		"IsNotAcceptable":        IsNotAcceptable,
+		"IsUnsupportedMediaType": IsUnsupportedMediaType,
+		"IsPayloadTooLarge":      IsPayloadTooLarge,
+		"IsMultiStatus":          IsMultiStatus,
+		"IsRetryable":            IsRetryable,
+		"IsTransient":            IsTransient,
+		"IsIdempotentSafe":       IsIdempotentSafe,
+		"IsClassified":           IsClassified,
+	}
+	for name, is := range tests {
+		t.Run(name, func(t *testing.T) {
+			if is(nil) {
+				t.Errorf("%s(nil) = true, want false", name)
+			}
+		})
+	}
+}
+
+func TestIsKindNil(t *testing.T) {
+	for _, k := range append(AllKinds(), KindUnknown) {
+		if Is(nil, k) {
+			t.Errorf("Is(nil, %s) = true, want false", k)
+		}
+	}
+}
+
+func TestHTTPStatusCodeDoesNotAllocate(t *testing.T) {
+	errs := []error{
+		NewNotFound("not found"),
+		WithCode(WrapInternal(NewBadInput("bad input"), "wrapped"), "code"),
+		WithStatus(NewConflict("conflict"), 422),
+	}
+	for _, err := range errs {
+		if allocs := testing.AllocsPerRun(100, func() { HTTPStatusCode(err) }); allocs != 0 {
+			t.Errorf("HTTPStatusCode(%v) allocates %v times, want 0", err, allocs)
+		}
+	}
+}
+
+func BenchmarkHTTPStatusCode(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		err  error
+	}{
+		{"type", NewNotFound("not found")},
+		{"metadata", WithTags(WithCode(WithDocURL(NewNotFound("not found"), "https://docs"), "code"), "team")},
+		{"override", WithStatus(NewConflict("conflict"), 422)},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				HTTPStatusCode(bm.err)
+			}
+		})
+	}
+}
+
+// isStatusCode is the former implementation of HTTPStatusCode, which calls every Is function in turn
+// each of them looks up the cause of the error again
+func isStatusCode(err error) int {
+	switch {
+	case IsBadInput(err):
+		return 400
+	case IsUnauthenticated(err):
+		return 401
+	case IsForbidden(err):
+		return 403
+	case IsNotFound(err):
+		return 404
+	case IsConflict(err):
+		return 409
+	case IsClientClosedRequest(err):
+		return 499
+	case IsTooManyRequests(err):
+		return 429
+	case IsServiceUnavailable(err):
+		return 503
+	case IsBadGateway(err):
+		return 502
+	case IsGatewayTimeout(err), IsTimeout(err):
+		return 504
+	case IsInternal(err):
+		return 500
+	case IsNotAcceptable(err):
+		return 406
+	case IsUnsupportedMediaType(err):
+		return 415
+	case IsPayloadTooLarge(err):
+		return 413
+	case IsMultiStatus(err):
+		return 207
+	}
+	return UnknownStatus
+}
+
+// BenchmarkHTTPStatusCodeImplementations compares the former implementation of HTTPStatusCode with the current one
+func BenchmarkHTTPStatusCodeImplementations(b *testing.B) {
+	err := WithTags(WithCode(WithDocURL(NewPayloadTooLarge("too large"), "https://docs"), "code"), "team")
+	b.Run("is", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			isStatusCode(err)
+		}
+	})
+	b.Run("current", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			HTTPStatusCode(err)
+		}
+	})
+}
+
+func TestWrapNil(t *testing.T) {
+	wraps := map[string]func(error, string) error{
+		"WrapBadInput":               WrapBadInput,
+		"WrapUnauthenticated":        WrapUnauthenticated,
+		"WrapUnauthenticatedExpired": WrapUnauthenticatedExpired,
+		"WrapUnauthenticatedInvalid": WrapUnauthenticatedInvalid,
+		"WrapForbidden":              WrapForbidden,
+		"WrapNotFound":               WrapNotFound,
+		"WrapConflict":               WrapConflict,
+		"WrapClientClosedRequest":    WrapClientClosedRequest,
+		"WrapTooManyRequests":        WrapTooManyRequests,
+		"WrapServiceUnavailable":     WrapServiceUnavailable,
+		"WrapBadGateway":             WrapBadGateway,
+		"WrapGatewayTimeout":         WrapGatewayTimeout,
+		"WrapTimeout":                WrapTimeout,
+		"WrapInternal":               WrapInternal,
+		"WrapNotAcceptable":          WrapNotAcceptable,
+		"WrapUnsupportedMediaType":   WrapUnsupportedMediaType,
+		"WrapPayloadTooLarge":        WrapPayloadTooLarge,
+	}
+	for name, wrap := range wraps {
+		t.Run(name, func(t *testing.T) {
+			if err := wrap(nil, "loading user"); err != nil {
+				t.Errorf("%s(nil) = %v, want nil", name, err)
+			}
+		})
+	}
+	for _, k := range append(AllKinds(), KindUnknown) {
+		if err := Reclassify(nil, k, "loading user"); err != nil {
+			t.Errorf("Reclassify(nil, %s) = %v, want nil", k, err)
+		}
+	}
+}
+
+func TestStatusCodeOr(t *testing.T) {
+	if got := StatusCodeOr(nil, 200); got != 200 {
+		t.Errorf("StatusCodeOr(nil, 200) = %d, want 200", got)
+	}
+	if got := StatusCodeOr(NewNotFound("not found"), 200); got != 404 {
+		t.Errorf("StatusCodeOr(NotFound, 200) = %d, want 404", got)
+	}
+}
@@ -0,0 +1,28 @@
+// Package errtypestest provides helpers for tests of code using errtypes, e.g. of HTTP handlers
+package errtypestest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/fvosberg/errtypes"
+)
+
+// AssertSafeResponse checks, that the body written for a server error (5xx) doesn't contain its message or the message of its root cause
+// so that internal details aren't leaked to clients. Client errors (4xx) aren't checked, because their messages are meant for clients
+func AssertSafeResponse(t testing.TB, err error, body []byte) {
+	t.Helper()
+	status := errtypes.HTTPStatusCode(err)
+	if status < 500 {
+		return
+	}
+	for _, msg := range []string{err.Error(), errtypes.RootMessage(err)} {
+		if msg == "" || msg == http.StatusText(status) {
+			continue
+		}
+		if bytes.Contains(body, []byte(msg)) {
+			t.Errorf("response body of status %d leaks the error message %q: %s", status, msg, body)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package errtypes
+
+// exitCodes maps the kinds to process exit codes, kinds without an entry exit with 1
+var exitCodes = map[Kind]int{
+	KindBadInput:             2,
+	KindNotAcceptable:        2,
+	KindUnsupportedMediaType: 2,
+	KindPayloadTooLarge:      2,
+	KindUnauthenticated:      3,
+	KindForbidden:            3,
+	KindNotFound:             4,
+	KindConflict:             5,
+	KindTooManyRequests:      6,
+	KindServiceUnavailable:   6,
+	KindBadGateway:           6,
+	KindGatewayTimeout:       6,
+	KindTimeout:              6,
+	KindClientClosedRequest:  130,
+}
+
+// ExitCode returns the process exit code for the error, e.g. for command line tools
+// it returns 0 for nil errors and 1 for errors, which don't match any of the types of this package
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[KindOf(err)]; ok {
+		return code
+	}
+	return 1
+}
+
+// RegisterExitCode overrides the exit code returned by ExitCode for errors of the kind
+// It's meant to be called during initialization
+func RegisterExitCode(kind Kind, code int) {
+	exitCodes[kind] = code
+}
@@ -0,0 +1,36 @@
+module github.com/fvosberg/errtypes
+
+go 1.26.0
+
+require (
+	github.com/99designs/gqlgen v0.17.40
+	github.com/go-playground/validator/v10 v10.30.5
+	github.com/golang-jwt/jwt/v4 v4.5.0
+	github.com/hashicorp/go-multierror v1.1.1
+	github.com/jackc/pgx/v5 v5.5.0
+	github.com/pkg/errors v0.9.1
+	github.com/redis/go-redis/v9 v9.22.0
+	github.com/vektah/gqlparser/v2 v2.5.10
+	google.golang.org/api v0.150.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20231030173426-d783a09b4405
+	google.golang.org/grpc v1.60.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/gabriel-vasile/mimetype v1.4.15 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/google/uuid v1.4.0 // indirect
+	github.com/hashicorp/errwrap v1.0.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	github.com/leodido/go-urn v1.5.0 // indirect
+	github.com/sosodev/duration v1.1.0 // indirect
+	go.uber.org/atomic v1.11.0 // indirect
+	golang.org/x/crypto v0.57.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+)
@@ -0,0 +1,21 @@
+// Package googleapierr converts errors of google.golang.org/api/googleapi into the types of errtypes
+package googleapierr
+
+import (
+	"github.com/fvosberg/errtypes"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+)
+
+// FromGoogleAPI reclassifies a *googleapi.Error with the type corresponding to its HTTP status code, keeping its message
+// the *googleapi.Error with its details stays reachable via Unwrap. Errors with an unmapped status code and other errors are returned unchanged
+func FromGoogleAPI(err error) error {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return err
+	}
+	if typed := errtypes.FromHTTPStatus(gerr.Code, ""); errtypes.IsClassified(typed) {
+		return errtypes.Reclassify(err, errtypes.KindOf(typed), "")
+	}
+	return err
+}
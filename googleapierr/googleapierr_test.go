@@ -0,0 +1,26 @@
+package googleapierr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fvosberg/errtypes"
+	"google.golang.org/api/googleapi"
+)
+
+func TestFromGoogleAPI(t *testing.T) {
+	gerr := &googleapi.Error{Code: 404, Message: "bucket not found"}
+	err := FromGoogleAPI(gerr)
+	if !errtypes.IsNotFound(err) {
+		t.Errorf("IsNotFound() = false, want true")
+	}
+	var got *googleapi.Error
+	if !errors.As(err, &got) || got != gerr {
+		t.Error("the *googleapi.Error isn't reachable via Unwrap")
+	}
+
+	other := errors.New("other")
+	if got := FromGoogleAPI(other); got != other {
+		t.Errorf("FromGoogleAPI() = %v, want the error unchanged", got)
+	}
+}
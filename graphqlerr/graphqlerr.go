@@ -0,0 +1,39 @@
+// Package graphqlerr converts the types of errtypes into errors of github.com/99designs/gqlgen
+package graphqlerr
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fvosberg/errtypes"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Present is an error presenter for gqlgen, which sets the extension "code" to the code of the error or the upper case name of its kind, e.g. "NOT_FOUND"
+// the messages of server errors (5xx) are replaced by the reason phrase, like in the HTTP responses. Errors of gqlgen itself, e.g. of the query validation, are presented unchanged
+func Present(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr.Err == nil {
+		return gqlErr
+	}
+	typed := gqlErr.Err
+	if status := errtypes.HTTPStatusCode(typed); status >= 500 {
+		gqlErr.Message = errtypes.ReasonPhrase(typed)
+	} else {
+		gqlErr.Message = errtypes.ResponseRedactor(typed.Error())
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = code(typed)
+	return gqlErr
+}
+
+// code returns the code of the error, or the upper case name of its kind, if it hasn't got one
+func code(err error) string {
+	if c := errtypes.Code(err); c != "" {
+		return c
+	}
+	return strings.ToUpper(errtypes.KindOf(err).String())
+}
@@ -0,0 +1,177 @@
+// Package grpcerr converts the types of errtypes into gRPC statuses and back
+package grpcerr
+
+import (
+	"sort"
+
+	"github.com/fvosberg/errtypes"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus returns the gRPC status corresponding to the kind of the error
+// the messages of server errors (5xx) are replaced by the reason phrase, to not leak internal details,
+// the ones of client errors are passed through errtypes.ResponseRedactor.
+// Validation errors carry their fields as BadRequest field violations. For nil errors the status is OK
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	msg := errtypes.ResponseRedactor(err.Error())
+	if errtypes.HTTPStatusCode(err) >= 500 {
+		msg = errtypes.ReasonPhrase(err)
+	}
+	st := status.New(Code(err), msg)
+	var verr *errtypes.ValidationError
+	if errtypes.KindOf(err) != errtypes.KindBadInput || !errors.As(err, &verr) {
+		return st
+	}
+	fieldErrs := verr.FieldErrors()
+	fields := make([]string, 0, len(fieldErrs))
+	for f := range fieldErrs {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	br := &errdetails.BadRequest{}
+	for _, f := range fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f,
+			Description: fieldErrs[f],
+		})
+	}
+	if withDetails, err := st.WithDetails(br); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Code returns the gRPC code corresponding to the kind of the error
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	switch errtypes.KindOf(err) {
+	case errtypes.KindBadInput, errtypes.KindNotAcceptable, errtypes.KindUnsupportedMediaType, errtypes.KindPayloadTooLarge:
+		return codes.InvalidArgument
+	case errtypes.KindUnauthenticated:
+		return codes.Unauthenticated
+	case errtypes.KindForbidden:
+		return codes.PermissionDenied
+	case errtypes.KindNotFound:
+		return codes.NotFound
+	case errtypes.KindConflict:
+		return codes.AlreadyExists
+	case errtypes.KindClientClosedRequest:
+		return codes.Canceled
+	case errtypes.KindTooManyRequests:
+		return codes.ResourceExhausted
+	case errtypes.KindServiceUnavailable, errtypes.KindBadGateway:
+		return codes.Unavailable
+	case errtypes.KindGatewayTimeout, errtypes.KindTimeout:
+		return codes.DeadlineExceeded
+	case errtypes.KindInternal:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// FromGRPCStatus returns an error of the type corresponding to the code of the status, annotated with the code by WithGRPCCode
+// DeadlineExceeded results in a timeout and ResourceExhausted in a too many requests error, so that both are retryable.
+// BadRequest field violations of invalid arguments result in a validation error. For nil statuses and OK it returns nil
+func FromGRPCStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	var err error
+	if fields := fieldViolations(st); st.Code() == codes.InvalidArgument && len(fields) > 0 {
+		err = errtypes.NewValidationErrors(fields)
+	} else {
+		err = errtypes.New(kind(st.Code()), st.Message())
+	}
+	return WithGRPCCode(err, st.Code())
+}
+
+// kind returns the kind corresponding to the gRPC code
+func kind(code codes.Code) errtypes.Kind {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return errtypes.KindBadInput
+	case codes.Unauthenticated:
+		return errtypes.KindUnauthenticated
+	case codes.PermissionDenied:
+		return errtypes.KindForbidden
+	case codes.NotFound:
+		return errtypes.KindNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return errtypes.KindConflict
+	case codes.Canceled:
+		return errtypes.KindClientClosedRequest
+	case codes.ResourceExhausted:
+		return errtypes.KindTooManyRequests
+	case codes.Unavailable:
+		return errtypes.KindServiceUnavailable
+	case codes.DeadlineExceeded:
+		return errtypes.KindTimeout
+	case codes.Internal, codes.DataLoss:
+		return errtypes.KindInternal
+	default:
+		return errtypes.KindUnknown
+	}
+}
+
+// fieldViolations returns the fields of the BadRequest details of the status mapped to their descriptions
+func fieldViolations(st *status.Status) map[string]string {
+	fields := map[string]string{}
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.FieldViolations {
+			fields[v.Field] = v.Description
+		}
+	}
+	return fields
+}
+
+// WithGRPCCode annotates the error with the gRPC code it has been received with, e.g. for correlating telemetry
+// the classification of the error is preserved. It returns nil for nil errors
+func WithGRPCCode(err error, code codes.Code) error {
+	if err == nil {
+		return nil
+	}
+	return withGRPCCode{err: err, code: code}
+}
+
+// GRPCCode returns the gRPC code of the outermost WithGRPCCode of the chain
+func GRPCCode(err error) (codes.Code, bool) {
+	var w withGRPCCode
+	if errors.As(err, &w) {
+		return w.code, true
+	}
+	return codes.OK, false
+}
+
+// withGRPCCode is the wrapper returned by WithGRPCCode
+type withGRPCCode struct {
+	err  error
+	code codes.Code
+}
+
+// Error returns the string representation of the wrapped error
+func (e withGRPCCode) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withGRPCCode) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withGRPCCode) Unwrap() error {
+	return e.err
+}
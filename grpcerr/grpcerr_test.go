@@ -0,0 +1,114 @@
+package grpcerr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fvosberg/errtypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromGRPCStatus(t *testing.T) {
+	tests := []struct {
+		code      codes.Code
+		kind      errtypes.Kind
+		retryable bool
+	}{
+		{codes.DeadlineExceeded, errtypes.KindTimeout, true},
+		{codes.ResourceExhausted, errtypes.KindTooManyRequests, true},
+		{codes.Unavailable, errtypes.KindServiceUnavailable, true},
+		{codes.InvalidArgument, errtypes.KindBadInput, false},
+		{codes.NotFound, errtypes.KindNotFound, false},
+		{codes.Unknown, errtypes.KindUnknown, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			err := FromGRPCStatus(status.New(tt.code, "message"))
+			if got := errtypes.KindOf(err); got != tt.kind {
+				t.Errorf("KindOf() = %s, want %s", got, tt.kind)
+			}
+			if got := errtypes.IsRetryable(err); got != tt.retryable {
+				t.Errorf("IsRetryable() = %t, want %t", got, tt.retryable)
+			}
+			if got, _ := GRPCCode(err); got != tt.code {
+				t.Errorf("GRPCCode() = %s, want %s", got, tt.code)
+			}
+			if got := err.Error(); got != "message" {
+				t.Errorf("Error() = %q, want %q", got, "message")
+			}
+		})
+	}
+}
+
+func TestFromGRPCStatusOK(t *testing.T) {
+	if err := FromGRPCStatus(nil); err != nil {
+		t.Errorf("FromGRPCStatus(nil) = %v, want nil", err)
+	}
+	if err := FromGRPCStatus(status.New(codes.OK, "")); err != nil {
+		t.Errorf("FromGRPCStatus(OK) = %v, want nil", err)
+	}
+}
+
+func TestFromGRPCStatusFieldViolations(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name", Description: "is required"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := errtypes.FieldErrorMessage(FromGRPCStatus(st), "name"); !ok || got != "is required" {
+		t.Errorf("FieldErrorMessage() = %q, %t, want %q", got, ok, "is required")
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	validation := errtypes.NewValidationErrors(map[string]string{"name": "is required"})
+	tests := []struct {
+		name       string
+		err        error
+		code       codes.Code
+		message    string
+		violations int
+	}{
+		{"client error", errtypes.NewNotFound("user not found"), codes.NotFound, "user not found", 0},
+		{"internal", errtypes.NewInternal("sql: connection refused"), codes.Internal, "Internal Server Error", 0},
+		{"unavailable", errtypes.NewServiceUnavailable("redis down"), codes.Unavailable, "Service Unavailable", 0},
+		{"timeout", errtypes.NewGatewayTimeout("upstream slow"), codes.DeadlineExceeded, "Gateway Timeout", 0},
+		{"validation", validation, codes.InvalidArgument, validation.Error(), 1},
+		{"wrapped validation", errtypes.WrapInternal(validation, ""), codes.Internal, "Internal Server Error", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := ToStatus(tt.err)
+			if st.Code() != tt.code {
+				t.Errorf("Code() = %s, want %s", st.Code(), tt.code)
+			}
+			if st.Message() != tt.message {
+				t.Errorf("Message() = %q, want %q", st.Message(), tt.message)
+			}
+			var violations int
+			for _, d := range st.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					violations += len(br.FieldViolations)
+				}
+			}
+			if violations != tt.violations {
+				t.Errorf("got %d field violations, want %d", violations, tt.violations)
+			}
+		})
+	}
+}
+
+func TestToStatusRedactsClientErrors(t *testing.T) {
+	defer func(redactor func(string) string) { errtypes.ResponseRedactor = redactor }(errtypes.ResponseRedactor)
+	errtypes.ResponseRedactor = func(s string) string {
+		return strings.ReplaceAll(s, "jane@example.com", "[email]")
+	}
+
+	st := ToStatus(errtypes.NewNotFound("user jane@example.com not found"))
+	if got, want := st.Message(), "user [email] not found"; got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,380 @@
+package errtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// errorResponse is the JSON body written by WriteHTTPError
+type errorResponse struct {
+	Error      string                `json:"error"`
+	Code       string                `json:"code,omitempty"`
+	Status     int                   `json:"status,omitempty"`
+	Reason     Reason                `json:"reason,omitempty"`
+	Hint       string                `json:"hint,omitempty"`
+	Strategy   MergeStrategy         `json:"strategy,omitempty"`
+	ExistingID string                `json:"existing_id,omitempty"`
+	Fields     map[string]string     `json:"fields,omitempty"`
+	Supported  []string              `json:"supported,omitempty"`
+	Action     string                `json:"action,omitempty"`
+	ErrorID    string                `json:"error_id,omitempty"`
+	Docs       string                `json:"docs,omitempty"`
+	Items      map[string]ItemResult `json:"items,omitempty"`
+}
+
+// responseFieldNames are the JSON keys of the message, code and status in the responses, set by SetResponseFieldNames
+var responseFieldNames = map[string]string{"error": "error", "code": "code", "status": "status"}
+
+// SetResponseFieldNames sets the JSON keys of the message, code and status in the responses, e.g. "detail" instead of "error"
+// empty names reset the key to its default. The keys are used for decoding responses in FromHTTPResponse as well.
+// It panics, if the names collide with each other or with the other keys of the responses, e.g. "reason"
+func SetResponseFieldNames(message, code, status string) {
+	names := map[string]string{"error": message, "code": code, "status": status}
+	used := map[string]bool{}
+	for key, name := range names {
+		if name == "" {
+			name = key
+			names[key] = name
+		}
+		if used[name] {
+			panic(fmt.Sprintf("errtypes: duplicate response field name %q", name))
+		}
+		used[name] = true
+	}
+	t := reflect.TypeOf(errorResponse{})
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if _, renamable := names[key]; !renamable && used[key] {
+			panic(fmt.Sprintf("errtypes: response field name %q is reserved", key))
+		}
+	}
+	for key, name := range names {
+		responseFieldNames[key] = name
+	}
+}
+
+// MarshalJSON returns the JSON representation of the response with the keys set by SetResponseFieldNames
+func (r errorResponse) MarshalJSON() ([]byte, error) {
+	type plain errorResponse
+	b, err := json.Marshal(plain(r))
+	if err != nil {
+		return nil, err
+	}
+	return renameKeys(b, func(key string) string {
+		if name, ok := responseFieldNames[key]; ok {
+			return name
+		}
+		return key
+	})
+}
+
+// UnmarshalJSON parses a response with the keys set by SetResponseFieldNames
+func (r *errorResponse) UnmarshalJSON(b []byte) error {
+	b, err := renameKeys(b, responseKey)
+	if err != nil {
+		return err
+	}
+	type plain errorResponse
+	return json.Unmarshal(b, (*plain)(r))
+}
+
+// responseKey returns the default key of a key set by SetResponseFieldNames
+func responseKey(name string) string {
+	for key, n := range responseFieldNames {
+		if n == name {
+			return key
+		}
+	}
+	return name
+}
+
+// receivedResponse is the JSON body of an error response of another service, read by FromHTTPStatusWithBody
+// besides the body of WriteHTTPError it supports the message under the key "message" and fields of any type
+type receivedResponse struct {
+	Error   string                 `json:"error"`
+	Message string                 `json:"message"`
+	Code    string                 `json:"code"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// UnmarshalJSON parses a response with the keys set by SetResponseFieldNames
+func (r *receivedResponse) UnmarshalJSON(b []byte) error {
+	b, err := renameKeys(b, responseKey)
+	if err != nil {
+		return err
+	}
+	type plain receivedResponse
+	return json.Unmarshal(b, (*plain)(r))
+}
+
+// renameKeys renames the keys of a JSON object
+func renameKeys(b []byte, rename func(string) string) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	renamed := make(map[string]json.RawMessage, len(obj))
+	for k, v := range obj {
+		renamed[rename(k)] = v
+	}
+	return json.Marshal(renamed)
+}
+
+// WriteHTTPError writes the status code of the error and a JSON body with its message
+// if the error carries a Retry-After, it's set as header in seconds
+// the messages of server errors (5xx) are replaced by the reason phrase, to not leak internal details
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	status, body := Respond(err)
+	setRetryAfter(w, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// WriteHTTPErrorNegotiated writes the error like WriteHTTPError, but with a plain text body of the message,
+// if the Accept header of the request prefers text/plain over application/json, e.g. for curl users
+func WriteHTTPErrorNegotiated(w http.ResponseWriter, r *http.Request, err error) {
+	if !prefersPlainText(r.Header.Get("Accept")) {
+		WriteHTTPError(w, err)
+		return
+	}
+	status := HTTPStatusCode(err)
+	setRetryAfter(w, err)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, newErrorResponse(err, status).Error+"\n")
+}
+
+// setRetryAfter sets the Retry-After header in seconds, if the error carries a Retry-After
+func setRetryAfter(w http.ResponseWriter, err error) {
+	if d, ok := RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+}
+
+// prefersPlainText checks, whether the Accept header has a higher quality for text/plain than for application/json
+// wildcards are taken into account, for ties and empty headers JSON is preferred
+func prefersPlainText(accept string) bool {
+	var jsonQ, textQ float64
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, p := range params[1:] {
+			if v := strings.TrimSpace(p); strings.HasPrefix(v, "q=") {
+				if f, err := strconv.ParseFloat(v[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		switch mediaType {
+		case "application/json", "application/*":
+			jsonQ = math.Max(jsonQ, q)
+		case "text/plain", "text/*":
+			textQ = math.Max(textQ, q)
+		case "*/*":
+			jsonQ = math.Max(jsonQ, q)
+			textQ = math.Max(textQ, q)
+		}
+	}
+	return textQ > jsonQ
+}
+
+// Respond returns the HTTP status code of the error and the JSON body written by WriteHTTPError
+// for handlers, which write the response themselves. Like HTTPStatusCode it panics for nil values
+func Respond(err error) (status int, body []byte) {
+	status = HTTPStatusCode(err)
+	body, _ = json.Marshal(newErrorResponse(err, status))
+	return status, body
+}
+
+// ErrorHandler returns a handler, which always responds with the error via WriteHTTPError, e.g. for disabled routes
+func ErrorHandler(err error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteHTTPError(w, err)
+	})
+}
+
+// ExposeValidationDetails controls, whether the responses for validation errors contain the invalid fields
+// if it's disabled, they only contain a generic message, e.g. for security sensitive forms
+var ExposeValidationDetails = true
+
+// ResponseRedactor is applied to the messages of client errors (4xx) before they are written by the response helpers,
+// e.g. to scrub emails from all error bodies. It defaults to the identity
+var ResponseRedactor = func(s string) string {
+	return s
+}
+
+// newErrorResponse returns the body for the error, hiding the details of server errors
+func newErrorResponse(err error, status int) errorResponse {
+	if ie, ok := cause(err).(*ItemErrors); ok {
+		// the results of the items carry their own messages, which are already safe
+		return errorResponse{Error: statusText(status), Status: status, Docs: DocURL(err), Items: ie.Results()}
+	}
+	if status >= 500 {
+		id, _ := ErrorID(err)
+		return errorResponse{Error: statusText(status), Status: status, Action: SuggestedAction(err), ErrorID: id, Docs: DocURL(err)}
+	}
+	res := errorResponse{
+		Error:      ResponseRedactor(err.Error()),
+		Code:       Code(err),
+		Status:     status,
+		Hint:       ConflictHint(err),
+		Strategy:   ConflictStrategy(err),
+		ExistingID: ConflictExistingID(err),
+		Supported:  SupportedMediaTypes(err),
+		Action:     SuggestedAction(err),
+		Docs:       DocURL(err),
+	}
+	if r, ok := cause(err).(interface{ Reason() Reason }); ok {
+		res.Reason = r.Reason()
+	}
+	if v, ok := cause(err).(*ValidationError); ok {
+		if !ExposeValidationDetails {
+			return errorResponse{Error: statusText(status), Status: status, Docs: res.Docs}
+		}
+		res.Fields = v.fields
+	}
+	return res
+}
+
+// MappedStatusCodes are the HTTP status codes, which FromHTTPStatus maps to a type of this package
+var MappedStatusCodes = []int{400, 401, 403, 404, 406, 409, 413, 415, 429, 499, 500, 502, 503, 504}
+
+// VerifyRoundTrip checks, whether FromHTTPStatus and HTTPStatusCode are consistent for all of the MappedStatusCodes
+// it can be used in tests to guard against both mappings drifting apart
+func VerifyRoundTrip() error {
+	for _, code := range MappedStatusCodes {
+		if got := HTTPStatusCode(FromHTTPStatus(code, "")); got != code {
+			return errors.Errorf("status code %d round trips to %d", code, got)
+		}
+	}
+	return nil
+}
+
+// FromHTTPStatus returns an error of the type corresponding to the HTTP status code with the message
+// if the message is empty, the reason phrase is used. It returns nil for status codes below 400
+func FromHTTPStatus(code int, s string) error {
+	if code < 400 {
+		return nil
+	}
+	if s == "" {
+		s = http.StatusText(code)
+	}
+	switch code {
+	case 400:
+		return NewBadInput(s)
+	case 401:
+		return NewUnauthenticated(s)
+	case 403:
+		return NewForbidden(s)
+	case 404:
+		return NewNotFound(s)
+	case 409:
+		return NewConflict(s)
+	case 499:
+		return NewClientClosedRequest(s)
+	case 429:
+		return NewTooManyRequests(s)
+	case 503:
+		return NewServiceUnavailable(s)
+	case 502:
+		return NewBadGateway(s)
+	case 504:
+		return NewGatewayTimeout(s)
+	case 500:
+		return NewInternal(s)
+	case 406:
+		return NewNotAcceptable(s)
+	case 415:
+		return NewUnsupportedMediaType(s)
+	case 413:
+		return NewPayloadTooLarge(s)
+	default:
+		return errors.New(s)
+	}
+}
+
+// FromHTTPResponse returns an error of the type corresponding to the status code of the response, or nil for status codes below 400
+// the message, code and fields are taken from a JSON body like the one of WriteHTTPError, if there is one. The body is read, but not closed
+func FromHTTPResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(resp.Body)
+	}
+	return FromHTTPStatusWithBody(resp.StatusCode, body)
+}
+
+// FromHTTPStatusWithBody returns an error of the type corresponding to the HTTP status code, or nil for status codes below 400
+// the message, code and fields are taken from a JSON body like the one of WriteHTTPError, the message is read from "message",
+// if there is no "error". Fields of bad input errors result in a ValidationError. If the body can't be parsed, the reason phrase is used as message
+func FromHTTPStatusWithBody(code int, body []byte) error {
+	if code < 400 {
+		return nil
+	}
+	var res receivedResponse
+	json.Unmarshal(body, &res)
+	msg := res.Error
+	if msg == "" {
+		msg = res.Message
+	}
+	var err error
+	if code == 400 && len(res.Fields) > 0 {
+		fields := make(map[string]string, len(res.Fields))
+		for k, v := range res.Fields {
+			if s, ok := v.(string); ok {
+				fields[k] = s
+			} else {
+				fields[k] = fmt.Sprint(v)
+			}
+		}
+		err = NewValidationErrors(fields)
+	} else {
+		err = FromHTTPStatus(code, msg)
+		if len(res.Fields) > 0 {
+			err = withFields{err: err, fields: res.Fields}
+		}
+	}
+	if res.Code != "" {
+		err = WithCode(err, res.Code)
+	}
+	return err
+}
+
+// FromHTTP classifies errors of the standard http package, e.g. *http.MaxBytesError of http.MaxBytesReader as payload too large
+// the message is kept. Other errors are returned unchanged
+func FromHTTP(err error) error {
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		return WrapPayloadTooLarge(err, "")
+	}
+	return err
+}
+
+// FromStatusCoded classifies errors of third party libraries, which implement StatusCode() int, by their HTTP status code
+// the error is reclassified with its message, so that it stays reachable via Unwrap. It checks the whole chain,
+// the outermost status code wins. Errors without or with an unmapped status code are returned unchanged
+func FromStatusCoded(err error) error {
+	for _, e := range chain(err) {
+		sc, ok := e.(interface{ StatusCode() int })
+		if !ok {
+			continue
+		}
+		if typed := FromHTTPStatus(sc.StatusCode(), ""); IsClassified(typed) {
+			return Reclassify(err, KindOf(typed), "")
+		}
+		return err
+	}
+	return err
+}
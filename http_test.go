@@ -0,0 +1,167 @@
+package errtypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestVerifyRoundTrip(t *testing.T) {
+	if err := VerifyRoundTrip(); err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range MappedStatusCodes {
+		t.Run(strconv.Itoa(code), func(t *testing.T) {
+			err := FromHTTPStatus(code, "")
+			if !IsClassified(err) {
+				t.Fatalf("FromHTTPStatus(%d) isn't classified", code)
+			}
+			if got := HTTPStatusCode(err); got != code {
+				t.Errorf("HTTPStatusCode(FromHTTPStatus(%d)) = %d", code, got)
+			}
+		})
+	}
+}
+
+type statusCodedError struct {
+	code int
+}
+
+func (e statusCodedError) Error() string   { return "upstream failed" }
+func (e statusCodedError) StatusCode() int { return e.code }
+
+func TestFromStatusCoded(t *testing.T) {
+	orig := statusCodedError{code: 404}
+	err := FromStatusCoded(fmt.Errorf("fetching user: %w", orig))
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound() = false, want true")
+	}
+	if got, want := err.Error(), "fetching user: upstream failed"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, orig) {
+		t.Error("the original error isn't reachable via Unwrap")
+	}
+
+	unmapped := statusCodedError{code: 302}
+	if got := FromStatusCoded(unmapped); got != unmapped {
+		t.Errorf("FromStatusCoded() = %v, want the error unchanged", got)
+	}
+}
+
+func TestRespondUnknownServerStatus(t *testing.T) {
+	status, body := Respond(WithStatus(errors.New("secret"), 599))
+	if status != 599 {
+		t.Errorf("status = %d, want 599", status)
+	}
+	var res errorResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Error != http.StatusText(500) {
+		t.Errorf("error = %q, want %q", res.Error, http.StatusText(500))
+	}
+}
+
+func TestSetResponseFieldNames(t *testing.T) {
+	defer SetResponseFieldNames("", "", "")
+
+	SetResponseFieldNames("detail", "", "")
+	_, body := Respond(WithCode(NewNotFound("user not found"), "user_missing"))
+	var res map[string]interface{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res["detail"] != "user not found" || res["code"] != "user_missing" {
+		t.Errorf("body = %s, want the message as detail and the code as code", body)
+	}
+}
+
+func TestSetResponseFieldNamesCollisions(t *testing.T) {
+	defer SetResponseFieldNames("", "", "")
+
+	tests := []struct {
+		name                  string
+		message, code, status string
+	}{
+		{"message as code", "code", "", ""},
+		{"code as status", "", "status", ""},
+		{"same names", "detail", "detail", ""},
+		{"reserved", "reason", "", ""},
+		{"reserved of items", "", "", "items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("SetResponseFieldNames() didn't panic")
+				}
+			}()
+			SetResponseFieldNames(tt.message, tt.code, tt.status)
+		})
+	}
+	if got := responseFieldNames["error"]; got != "error" {
+		t.Errorf("the names have been changed to %q by a rejected call", got)
+	}
+}
+
+func TestRespondMaskedForbidden(t *testing.T) {
+	forbidden := WithDocURL(WithAction(WithCode(NewForbidden("user 7 lacks role admin on doc 42"), "missing_role"), "ask an admin for the role"), "https://docs/roles")
+	status, body := Respond(MaskForbiddenAsNotFound(forbidden))
+	if status != 404 {
+		t.Errorf("status = %d, want 404", status)
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"error": "Not Found", "status": float64(404), "action": "check the identifier"}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("body = %s, want %v", body, want)
+	}
+}
+
+func TestFromHTTPStatusWithBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		body    string
+		message string
+		errCode string
+		fields  map[string]interface{}
+	}{
+		{"body of WriteHTTPError", 404, `{"error":"user gone","code":"user_missing"}`, "user gone", "user_missing", map[string]interface{}{}},
+		{"message", 404, `{"message":"user gone","code":"user_missing"}`, "user gone", "user_missing", map[string]interface{}{}},
+		{"fields of any type", 409, `{"message":"duplicate","code":"dup","fields":{"id":42,"name":"jane"}}`, "duplicate", "dup", map[string]interface{}{"id": float64(42), "name": "jane"}},
+		{"invalid body", 404, `not json`, "Not Found", "", map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromHTTPStatusWithBody(tt.code, []byte(tt.body))
+			if got := HTTPStatusCode(err); got != tt.code {
+				t.Errorf("HTTPStatusCode() = %d, want %d", got, tt.code)
+			}
+			if got := err.Error(); got != tt.message {
+				t.Errorf("Error() = %q, want %q", got, tt.message)
+			}
+			if got := Code(err); got != tt.errCode {
+				t.Errorf("Code() = %q, want %q", got, tt.errCode)
+			}
+			if got := Fields(err); !reflect.DeepEqual(got, tt.fields) {
+				t.Errorf("Fields() = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestFromHTTPStatusWithBodyValidation(t *testing.T) {
+	err := FromHTTPStatusWithBody(400, []byte(`{"message":"invalid","fields":{"name":"is required","age":18}}`))
+	want := map[string]string{"name": "is required", "age": "18"}
+	if v := validationError(err); v == nil || !reflect.DeepEqual(v.FieldErrors(), want) {
+		t.Errorf("FromHTTPStatusWithBody() = %v, want a validation error with the fields %v", err, want)
+	}
+}
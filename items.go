@@ -0,0 +1,123 @@
+package errtypes
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MultiStatus is used for errors of batch operations, where some items failed and others succeeded
+// The corresponding HTTP status code is 207
+type MultiStatus interface {
+	IsMultiStatus() bool
+}
+
+// IsMultiStatus checks, whether this error is caused by a batch operation, where some items failed and others succeeded
+// it returns false for nil errors
+func IsMultiStatus(err error) bool {
+	v, ok := cause(err).(MultiStatus)
+	return ok && v.IsMultiStatus()
+}
+
+// NewMultiStatus returns an ItemErrors with the results of the items by their IDs, nil for a success
+// it returns nil, if no item failed
+func NewMultiStatus(items map[string]error) error {
+	e := &ItemErrors{}
+	for id, err := range items {
+		e.Set(id, err)
+	}
+	return e.Err()
+}
+
+// ItemErrors collects the results of a batch operation per item, e.g. for partial success responses
+// if some items failed and others succeeded, its HTTP status code is 207 (Multi-Status)
+type ItemErrors struct {
+	items map[string]error
+}
+
+// ItemResult is the result of a single item of ItemErrors
+type ItemResult struct {
+	Status  int    `json:"status"`
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// Set records the result of the item with the ID, nil for a success
+func (e *ItemErrors) Set(id string, err error) {
+	if e.items == nil {
+		e.items = map[string]error{}
+	}
+	e.items[id] = err
+}
+
+// Results returns the results of all items by their IDs
+// succeeded items have the status code 200 and the messages of server errors are replaced by the reason phrase
+func (e *ItemErrors) Results() map[string]ItemResult {
+	results := make(map[string]ItemResult, len(e.items))
+	for id, err := range e.items {
+		if err == nil {
+			results[id] = ItemResult{Status: http.StatusOK}
+			continue
+		}
+		status := statusCode(err)
+		results[id] = ItemResult{Status: status, Message: newErrorResponse(err, status).Error, Code: Code(err)}
+	}
+	return results
+}
+
+// IsMultiStatus indicates, whether some items failed and others succeeded
+func (e *ItemErrors) IsMultiStatus() bool {
+	failed := len(e.failed())
+	return failed > 0 && failed < len(e.items)
+}
+
+// Err returns nil, if no item failed, and the ItemErrors otherwise
+func (e *ItemErrors) Err() error {
+	if len(e.failed()) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error returns the IDs of the failed items with their messages
+func (e *ItemErrors) Error() string {
+	failed := e.failed()
+	ids := make([]string, 0, len(failed))
+	for id := range failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%s: %s", id, failed[id].Error())
+	}
+	return fmt.Sprintf("%d of %d items failed: %s", len(failed), len(e.items), strings.Join(msgs, "; "))
+}
+
+// HTTPStatus returns 207, if some items failed and others succeeded, and the status of the most severe error, if all failed
+func (e *ItemErrors) HTTPStatus() int {
+	failed := e.failed()
+	if len(failed) == 0 {
+		return http.StatusOK
+	}
+	if e.IsMultiStatus() {
+		return http.StatusMultiStatus
+	}
+	errs := make([]error, 0, len(failed))
+	for _, err := range failed {
+		errs = append(errs, err)
+	}
+	return statusCode(MostSevere(errs...))
+}
+
+// failed returns the errors of the failed items by their IDs
+func (e *ItemErrors) failed() map[string]error {
+	failed := map[string]error{}
+	for id, err := range e.items {
+		if err != nil {
+			failed[id] = err
+		}
+	}
+	return failed
+}
@@ -0,0 +1,22 @@
+// Package jwterr converts errors of github.com/golang-jwt/jwt into the types of errtypes
+package jwterr
+
+import (
+	"github.com/fvosberg/errtypes"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+// From returns an unauthenticated error for validation errors of tokens, wrapping the original error
+// tokens, which are only expired, have the reason errtypes.ReasonExpired, all other invalid tokens errtypes.ReasonInvalid,
+// so that forged tokens are never reported as expired. Other errors are returned unchanged
+func From(err error) error {
+	var ve *jwt.ValidationError
+	if !errors.As(err, &ve) {
+		return err
+	}
+	if ve.Errors == jwt.ValidationErrorExpired {
+		return errtypes.WrapUnauthenticatedExpired(err, "token expired")
+	}
+	return errtypes.WrapUnauthenticatedInvalid(err, "invalid token")
+}
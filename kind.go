@@ -0,0 +1,410 @@
+package errtypes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Kind is the classification of an error by the types of this package
+type Kind int
+
+const (
+	// KindUnknown is used for errors, which don't match any of the types of this package
+	KindUnknown Kind = iota
+	// KindBadInput is used for BadInput errors
+	KindBadInput
+	// KindUnauthenticated is used for Unauthenticated errors
+	KindUnauthenticated
+	// KindForbidden is used for Forbidden errors
+	KindForbidden
+	// KindNotFound is used for NotFound errors
+	KindNotFound
+	// KindConflict is used for Conflict errors
+	KindConflict
+	// KindClientClosedRequest is used for ClientClosedRequest errors
+	KindClientClosedRequest
+	// KindTooManyRequests is used for TooManyRequests errors
+	KindTooManyRequests
+	// KindServiceUnavailable is used for ServiceUnavailable errors
+	KindServiceUnavailable
+	// KindBadGateway is used for BadGateway errors
+	KindBadGateway
+	// KindGatewayTimeout is used for GatewayTimeout errors
+	KindGatewayTimeout
+	// KindTimeout is used for Timeout errors
+	KindTimeout
+	// KindInternal is used for Internal errors
+	KindInternal
+	// KindNotAcceptable is used for NotAcceptable errors
+	KindNotAcceptable
+	// KindUnsupportedMediaType is used for UnsupportedMediaType errors
+	KindUnsupportedMediaType
+	// KindPayloadTooLarge is used for PayloadTooLarge errors
+	KindPayloadTooLarge
+
+	// kindEnd marks the end of the kinds, new kinds have to be added before it
+	kindEnd
+)
+
+// String returns the snake case name of the kind, e.g. "not_found"
+func (k Kind) String() string {
+	switch k {
+	case KindBadInput:
+		return "bad_input"
+	case KindUnauthenticated:
+		return "unauthenticated"
+	case KindForbidden:
+		return "forbidden"
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindClientClosedRequest:
+		return "client_closed_request"
+	case KindTooManyRequests:
+		return "too_many_requests"
+	case KindServiceUnavailable:
+		return "service_unavailable"
+	case KindBadGateway:
+		return "bad_gateway"
+	case KindGatewayTimeout:
+		return "gateway_timeout"
+	case KindTimeout:
+		return "timeout"
+	case KindInternal:
+		return "internal"
+	case KindNotAcceptable:
+		return "not_acceptable"
+	case KindUnsupportedMediaType:
+		return "unsupported_media_type"
+	case KindPayloadTooLarge:
+		return "payload_too_large"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON returns the JSON string of the snake case name of the kind, e.g. "not_found"
+func (k Kind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON parses the snake case name of a kind, names, which aren't known, result in KindUnknown
+// so that kinds added by newer versions of this package can still be decoded
+func (k *Kind) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.Wrap(err, "decoding kind failed")
+	}
+	*k = kindFromString(s)
+	return nil
+}
+
+// kindFromString returns the kind with the name, KindUnknown if there is none
+func kindFromString(s string) Kind {
+	for _, k := range AllKinds() {
+		if k.String() == s {
+			return k
+		}
+	}
+	return KindUnknown
+}
+
+// AllKinds returns all known kinds, excluding KindUnknown
+func AllKinds() []Kind {
+	kinds := make([]Kind, 0, kindEnd-1)
+	for k := KindUnknown + 1; k < kindEnd; k++ {
+		kinds = append(kinds, k)
+	}
+	return kinds
+}
+
+// KindInfo describes a kind, e.g. to generate API documentation
+type KindInfo struct {
+	Kind         Kind
+	Status       int
+	ReasonPhrase string
+}
+
+// Kinds returns the HTTP status code and reason phrase of all known kinds, excluding KindUnknown
+func Kinds() []KindInfo {
+	var infos []KindInfo
+	for _, k := range AllKinds() {
+		err := Sample(k)
+		infos = append(infos, KindInfo{Kind: k, Status: statusCode(err), ReasonPhrase: ReasonPhrase(err)})
+	}
+	return infos
+}
+
+// Sample returns a representative error of the kind, e.g. for table driven tests
+// for KindUnknown it returns an error, which doesn't match any of the types of this package
+func Sample(kind Kind) error {
+	switch kind {
+	case KindBadInput:
+		return NewBadInput("sample bad input")
+	case KindUnauthenticated:
+		return NewUnauthenticated("sample unauthenticated")
+	case KindForbidden:
+		return NewForbidden("sample forbidden")
+	case KindNotFound:
+		return NewNotFound("sample not found")
+	case KindConflict:
+		return NewConflict("sample conflict")
+	case KindClientClosedRequest:
+		return NewClientClosedRequest("sample client closed request")
+	case KindTooManyRequests:
+		return NewTooManyRequests("sample too many requests")
+	case KindServiceUnavailable:
+		return NewServiceUnavailable("sample service unavailable")
+	case KindBadGateway:
+		return NewBadGateway("sample bad gateway")
+	case KindGatewayTimeout:
+		return NewGatewayTimeout("sample gateway timeout")
+	case KindTimeout:
+		return NewTimeout("sample timeout")
+	case KindInternal:
+		return NewInternal("sample internal")
+	case KindNotAcceptable:
+		return NewNotAcceptable("sample not acceptable")
+	case KindUnsupportedMediaType:
+		return NewUnsupportedMediaType("sample unsupported media type")
+	case KindPayloadTooLarge:
+		return NewPayloadTooLarge("sample payload too large")
+	default:
+		return errors.New("sample unknown")
+	}
+}
+
+// New returns an error of the kind with the message
+// for KindUnknown it returns a plain error
+func New(kind Kind, s string) error {
+	switch kind {
+	case KindBadInput:
+		return NewBadInput(s)
+	case KindUnauthenticated:
+		return NewUnauthenticated(s)
+	case KindForbidden:
+		return NewForbidden(s)
+	case KindNotFound:
+		return NewNotFound(s)
+	case KindConflict:
+		return NewConflict(s)
+	case KindClientClosedRequest:
+		return NewClientClosedRequest(s)
+	case KindTooManyRequests:
+		return NewTooManyRequests(s)
+	case KindServiceUnavailable:
+		return NewServiceUnavailable(s)
+	case KindBadGateway:
+		return NewBadGateway(s)
+	case KindGatewayTimeout:
+		return NewGatewayTimeout(s)
+	case KindTimeout:
+		return NewTimeout(s)
+	case KindInternal:
+		return NewInternal(s)
+	case KindNotAcceptable:
+		return NewNotAcceptable(s)
+	case KindUnsupportedMediaType:
+		return NewUnsupportedMediaType(s)
+	case KindPayloadTooLarge:
+		return NewPayloadTooLarge(s)
+	default:
+		return errors.New(s)
+	}
+}
+
+// Newf returns an error of the kind - supports sprintf
+func Newf(kind Kind, s string, i ...interface{}) error {
+	return New(kind, fmt.Sprintf(s, i...))
+}
+
+// Reclassify returns an error of the kind, wrapping err, e.g. to normalize errors of third party libraries
+// the message is prefixed to the message of err, an empty message keeps it. For KindUnknown the returned error has no classification at all.
+// It returns nil for nil errors
+func Reclassify(err error, kind Kind, s string) error {
+	if err == nil {
+		return nil
+	}
+	switch kind {
+	case KindBadInput:
+		return WrapBadInput(err, s)
+	case KindUnauthenticated:
+		return WrapUnauthenticated(err, s)
+	case KindForbidden:
+		return WrapForbidden(err, s)
+	case KindNotFound:
+		return WrapNotFound(err, s)
+	case KindConflict:
+		return WrapConflict(err, s)
+	case KindClientClosedRequest:
+		return WrapClientClosedRequest(err, s)
+	case KindTooManyRequests:
+		return WrapTooManyRequests(err, s)
+	case KindServiceUnavailable:
+		return WrapServiceUnavailable(err, s)
+	case KindBadGateway:
+		return WrapBadGateway(err, s)
+	case KindGatewayTimeout:
+		return WrapGatewayTimeout(err, s)
+	case KindTimeout:
+		return WrapTimeout(err, s)
+	case KindInternal:
+		return WrapInternal(err, s)
+	case KindNotAcceptable:
+		return WrapNotAcceptable(err, s)
+	case KindUnsupportedMediaType:
+		return WrapUnsupportedMediaType(err, s)
+	case KindPayloadTooLarge:
+		return WrapPayloadTooLarge(err, s)
+	default:
+		return unclassifiedError{s: wrapMessage(s, err), cause: err}
+	}
+}
+
+// EnsureKind returns the error unchanged, if it matches any of the types of this package, and otherwise classifies it with Reclassify
+// It returns nil for nil errors
+func EnsureKind(err error, kind Kind, s string) error {
+	if err == nil || IsClassified(err) {
+		return err
+	}
+	return Reclassify(err, kind, s)
+}
+
+// unclassifiedError wraps an error, hiding its classification
+type unclassifiedError struct {
+	s     string
+	cause error
+}
+
+// Error returns the string representation of this error
+func (e unclassifiedError) Error() string {
+	return e.s
+}
+
+// Unwrap returns the error, which has been reclassified by this error
+func (e unclassifiedError) Unwrap() error {
+	return e.cause
+}
+
+// KindOf returns the kind of the error, KindUnknown for nil errors
+// if the error matches multiple types, the one with the highest priority wins - the same order as in HTTPStatusCode
+func KindOf(err error) Kind {
+	return layerKind(cause(err))
+}
+
+// KindsOf returns all kinds the error matches, in the same priority order as KindOf, e.g. for errors implementing multiple interfaces
+// it returns nil for nil errors and errors, which don't match any of the types of this package
+func KindsOf(err error) []Kind {
+	var kinds []Kind
+	for _, k := range AllKinds() {
+		if Is(err, k) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// OutermostKind returns the kind of the outermost classified error of the chain, e.g. the kind assigned at the last reclassification
+// unlike KindOf, which only looks at the innermost error of the Cause chain
+func OutermostKind(err error) Kind {
+	for _, err := range chain(err) {
+		if k := layerKind(err); k != KindUnknown {
+			return k
+		}
+	}
+	return KindUnknown
+}
+
+// IsClassified checks, whether the error matches any of the types of this package
+func IsClassified(err error) bool {
+	return KindOf(err) != KindUnknown
+}
+
+// Is checks, whether the error matches the kind
+// it returns false for nil errors, even for KindUnknown
+func Is(err error, kind Kind) bool {
+	switch kind {
+	case KindBadInput:
+		return IsBadInput(err)
+	case KindUnauthenticated:
+		return IsUnauthenticated(err)
+	case KindForbidden:
+		return IsForbidden(err)
+	case KindNotFound:
+		return IsNotFound(err)
+	case KindConflict:
+		return IsConflict(err)
+	case KindClientClosedRequest:
+		return IsClientClosedRequest(err)
+	case KindTooManyRequests:
+		return IsTooManyRequests(err)
+	case KindServiceUnavailable:
+		return IsServiceUnavailable(err)
+	case KindBadGateway:
+		return IsBadGateway(err)
+	case KindGatewayTimeout:
+		return IsGatewayTimeout(err)
+	case KindTimeout:
+		return IsTimeout(err)
+	case KindInternal:
+		return IsInternal(err)
+	case KindNotAcceptable:
+		return IsNotAcceptable(err)
+	case KindUnsupportedMediaType:
+		return IsUnsupportedMediaType(err)
+	case KindPayloadTooLarge:
+		return IsPayloadTooLarge(err)
+	default:
+		return err != nil && KindOf(err) == KindUnknown
+	}
+}
+
+// IsAny checks, whether the error matches any of the kinds, e.g. IsAny(err, KindNotFound, KindConflict)
+// it returns false for nil errors and if no kinds are given
+func IsAny(err error, kinds ...Kind) bool {
+	for _, k := range kinds {
+		if Is(err, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// layerKind returns the kind of this error itself, without looking into its causes
+func layerKind(err error) Kind {
+	if v, ok := err.(BadInput); ok && v.IsBadInput() {
+		return KindBadInput
+	} else if v, ok := err.(Unauthenticated); ok && v.IsUnauthenticated() {
+		return KindUnauthenticated
+	} else if v, ok := err.(Forbidden); ok && v.IsForbidden() {
+		return KindForbidden
+	} else if v, ok := err.(NotFound); ok && v.IsNotFound() {
+		return KindNotFound
+	} else if v, ok := err.(Conflict); ok && v.IsConflict() {
+		return KindConflict
+	} else if v, ok := err.(ClientClosedRequest); ok && v.IsClientClosedRequest() {
+		return KindClientClosedRequest
+	} else if v, ok := err.(TooManyRequests); ok && v.IsTooManyRequests() {
+		return KindTooManyRequests
+	} else if v, ok := err.(ServiceUnavailable); ok && v.IsServiceUnavailable() {
+		return KindServiceUnavailable
+	} else if v, ok := err.(BadGateway); ok && v.IsBadGateway() {
+		return KindBadGateway
+	} else if v, ok := err.(GatewayTimeout); ok && v.IsGatewayTimeout() {
+		return KindGatewayTimeout
+	} else if v, ok := err.(Timeout); ok && v.IsTimeout() {
+		return KindTimeout
+	} else if v, ok := err.(Internal); ok && v.IsInternal() {
+		return KindInternal
+	} else if v, ok := err.(NotAcceptable); ok && v.IsNotAcceptable() {
+		return KindNotAcceptable
+	} else if v, ok := err.(UnsupportedMediaType); ok && v.IsUnsupportedMediaType() {
+		return KindUnsupportedMediaType
+	} else if v, ok := err.(PayloadTooLarge); ok && v.IsPayloadTooLarge() {
+		return KindPayloadTooLarge
+	}
+	return KindUnknown
+}
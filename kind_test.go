@@ -0,0 +1,29 @@
+package errtypes
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestKindsRoundTrip(t *testing.T) {
+	for _, k := range AllKinds() {
+		t.Run(k.String(), func(t *testing.T) {
+			if got := KindOf(Sample(k)); got != k {
+				t.Errorf("KindOf(Sample()) = %v", got)
+			}
+			if got := KindOf(New(k, "")); got != k {
+				t.Errorf("KindOf(New()) = %v", got)
+			}
+			if got := KindOf(Reclassify(errors.New("third party"), k, "")); got != k {
+				t.Errorf("KindOf(Reclassify()) = %v", got)
+			}
+			if !Is(New(k, ""), k) {
+				t.Errorf("Is(New()) = false")
+			}
+			if got := kindFromString(k.String()); got != k {
+				t.Errorf("kindFromString(%q) = %v", k.String(), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package errtypes
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// WithTags annotates the error with tags, e.g. for routing alerts to the right team
+// the classification of the error is preserved. It returns nil for nil errors
+func WithTags(err error, tags ...string) error {
+	if err == nil {
+		return nil
+	}
+	return withTags{err: err, tags: tags}
+}
+
+// Tags returns the deduplicated tags of all errors of the chain, starting with the outermost
+func Tags(err error) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, err := range chain(err) {
+		t, ok := err.(withTags)
+		if !ok {
+			continue
+		}
+		for _, tag := range t.tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// withTags is the wrapper returned by WithTags
+type withTags struct {
+	err  error
+	tags []string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withTags) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withTags) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withTags) Unwrap() error {
+	return e.err
+}
+
+// WithCode annotates the error with a machine readable code, e.g. "user_archived"
+// the classification of the error is preserved. It returns nil for nil errors
+func WithCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	return withCode{err: err, code: code}
+}
+
+// Code returns the code of the outermost error of the chain, which implements Code() string
+// it returns an empty string, if there is none. Codes below MaskForbiddenAsNotFound aren't returned
+func Code(err error) string {
+	for _, err := range publicChain(err) {
+		if c, ok := err.(interface{ Code() string }); ok {
+			return c.Code()
+		}
+	}
+	return ""
+}
+
+// HasCode checks, whether any error of the chain has the code
+func HasCode(err error, code string) bool {
+	for _, err := range chain(err) {
+		if c, ok := err.(interface{ Code() string }); ok && c.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// withCode is the wrapper returned by WithCode
+type withCode struct {
+	err  error
+	code string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withCode) Error() string {
+	return e.err.Error()
+}
+
+// Code returns the code of this error
+func (e withCode) Code() string {
+	return e.code
+}
+
+// Cause returns the wrapped error
+func (e withCode) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withCode) Unwrap() error {
+	return e.err
+}
+
+// WithUpstreamStatus annotates the error with the HTTP status code received from an upstream service
+// it doesn't affect HTTPStatusCode. It returns nil for nil errors
+func WithUpstreamStatus(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return withUpstreamStatus{err: err, code: code}
+}
+
+// UpstreamStatus returns the upstream status code of the outermost error of the chain, which implements UpstreamStatus() int
+// a status code of 0 is treated as unknown
+func UpstreamStatus(err error) (int, bool) {
+	for _, err := range chain(err) {
+		if u, ok := err.(interface{ UpstreamStatus() int }); ok && u.UpstreamStatus() != 0 {
+			return u.UpstreamStatus(), true
+		}
+	}
+	return 0, false
+}
+
+// withUpstreamStatus is the wrapper returned by WithUpstreamStatus
+type withUpstreamStatus struct {
+	err  error
+	code int
+}
+
+// Error returns the string representation of the wrapped error
+func (e withUpstreamStatus) Error() string {
+	return e.err.Error()
+}
+
+// UpstreamStatus returns the status code received from the upstream service
+func (e withUpstreamStatus) UpstreamStatus() int {
+	return e.code
+}
+
+// Cause returns the wrapped error
+func (e withUpstreamStatus) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withUpstreamStatus) Unwrap() error {
+	return e.err
+}
+
+// WithDocURL annotates the error with a link to its documentation, which is included in the responses
+// the classification of the error is preserved. It returns nil for nil errors
+func WithDocURL(err error, url string) error {
+	if err == nil {
+		return nil
+	}
+	return withDocURL{err: err, url: url}
+}
+
+// DocURL returns the documentation link of the outermost error of the chain, which implements DocURL() string
+// it returns an empty string, if there is none. Links below MaskForbiddenAsNotFound aren't returned
+func DocURL(err error) string {
+	for _, err := range publicChain(err) {
+		if d, ok := err.(interface{ DocURL() string }); ok {
+			return d.DocURL()
+		}
+	}
+	return ""
+}
+
+// withDocURL is the wrapper returned by WithDocURL
+type withDocURL struct {
+	err error
+	url string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withDocURL) Error() string {
+	return e.err.Error()
+}
+
+// DocURL returns the documentation link of this error
+func (e withDocURL) DocURL() string {
+	return e.url
+}
+
+// Cause returns the wrapped error
+func (e withDocURL) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withDocURL) Unwrap() error {
+	return e.err
+}
+
+// WithField annotates the error with a key value pair, e.g. for structured logging
+// the classification of the error is preserved. It returns nil for nil errors
+func WithField(err error, key string, value interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return withFields{err: err, fields: map[string]interface{}{key: value}}
+}
+
+// WithFields annotates the error with several key value pairs in one wrapper, e.g. for structured logging
+// if err has been annotated by WithField or WithFields directly before, the fields are merged into a single wrapper,
+// the new values win. The classification of the error is preserved. It returns nil for nil errors
+func WithFields(err error, fields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(fields))
+	if w, ok := err.(withFields); ok {
+		for k, v := range w.fields {
+			merged[k] = v
+		}
+		err = w.err
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return withFields{err: err, fields: merged}
+}
+
+// Fields returns the fields of all errors of the chain, which implement Fields() map[string]interface{}
+// if a key is set multiple times, the value of the outermost error wins
+func Fields(err error) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, err := range chain(err) {
+		f, ok := err.(interface{ Fields() map[string]interface{} })
+		if !ok {
+			continue
+		}
+		for k, v := range f.Fields() {
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+	}
+	return fields
+}
+
+// withFields is the wrapper returned by WithField
+type withFields struct {
+	err    error
+	fields map[string]interface{}
+}
+
+// Error returns the string representation of the wrapped error
+func (e withFields) Error() string {
+	return e.err.Error()
+}
+
+// Fields returns the fields of this error
+func (e withFields) Fields() map[string]interface{} {
+	return e.fields
+}
+
+// Cause returns the wrapped error
+func (e withFields) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withFields) Unwrap() error {
+	return e.err
+}
+
+// Attributes returns all metadata of the chain in a single map, e.g. for structured logging
+// it contains the fields and the keys "kind", "status", "severity", "code", "tags", "request_id", "error_id", "upstream_status" and "doc_url",
+// which take precedence over fields with the same key. The severity is "error" for errors, which should be reported by ShouldReport,
+// e.g. server errors, and "warning" otherwise. The optional keys are only set, if there is a value
+func Attributes(err error) map[string]interface{} {
+	if err == nil {
+		return map[string]interface{}{}
+	}
+	attrs := Fields(err)
+	attrs["kind"] = KindOf(err).String()
+	attrs["status"] = statusCode(err)
+	attrs["severity"] = "warning"
+	if ShouldReport(err) {
+		attrs["severity"] = "error"
+	}
+	if code := Code(err); code != "" {
+		attrs["code"] = code
+	}
+	if tags := Tags(err); len(tags) > 0 {
+		attrs["tags"] = tags
+	}
+	if id := RequestID(err); id != "" {
+		attrs["request_id"] = id
+	}
+	if id, ok := ErrorID(err); ok {
+		attrs["error_id"] = id
+	}
+	if code, ok := UpstreamStatus(err); ok {
+		attrs["upstream_status"] = code
+	}
+	if url := DocURL(err); url != "" {
+		attrs["doc_url"] = url
+	}
+	return attrs
+}
+
+// Because appends the reason to the message of the error, e.g. "not found: because user is archived"
+// the classification of the error is preserved. It returns nil for nil errors
+func Because(err error, reason string) error {
+	if err == nil {
+		return nil
+	}
+	return because{err: err, reason: reason}
+}
+
+// because is the wrapper returned by Because
+type because struct {
+	err    error
+	reason string
+}
+
+// Error returns the string representation of the wrapped error, followed by the reason
+func (e because) Error() string {
+	return e.err.Error() + ": because " + e.reason
+}
+
+// Cause returns the wrapped error
+func (e because) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e because) Unwrap() error {
+	return e.err
+}
+
+// WithRequestID annotates the error with the ID of the request, which caused it, e.g. a trace ID
+// the classification of the error is preserved. It returns nil for nil errors
+func WithRequestID(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+	return withRequestID{err: err, id: id}
+}
+
+// RequestID returns the request ID of the outermost error of the chain, which implements RequestID() string
+// it returns an empty string, if there is none
+func RequestID(err error) string {
+	for _, err := range chain(err) {
+		if r, ok := err.(interface{ RequestID() string }); ok {
+			return r.RequestID()
+		}
+	}
+	return ""
+}
+
+// withRequestID is the wrapper returned by WithRequestID
+type withRequestID struct {
+	err error
+	id  string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withRequestID) Error() string {
+	return e.err.Error()
+}
+
+// RequestID returns the ID of the request, which caused this error
+func (e withRequestID) RequestID() string {
+	return e.id
+}
+
+// Cause returns the wrapped error
+func (e withRequestID) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withRequestID) Unwrap() error {
+	return e.err
+}
+
+// MarkLogged marks the error as logged, so that outer layers can skip logging it again
+// the classification of the error is preserved. It returns nil for nil errors
+func MarkLogged(err error) error {
+	if err == nil {
+		return nil
+	}
+	return loggedError{err: err}
+}
+
+// WasLogged checks, whether any error of the chain has been marked as logged
+func WasLogged(err error) bool {
+	for _, err := range chain(err) {
+		if _, ok := err.(loggedError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// loggedError is the wrapper returned by MarkLogged
+type loggedError struct {
+	err error
+}
+
+// Error returns the string representation of the wrapped error
+func (e loggedError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e loggedError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e loggedError) Unwrap() error {
+	return e.err
+}
+
+// MarkReportable marks the error to be reported to an error tracker, regardless of its status code
+// the classification of the error is preserved. It returns nil for nil errors
+func MarkReportable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return reportableError{err: err, report: true}
+}
+
+// MarkNotReportable marks the error to not be reported to an error tracker, regardless of its status code
+// the classification of the error is preserved. It returns nil for nil errors
+func MarkNotReportable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return reportableError{err: err, report: false}
+}
+
+// ShouldReport checks, whether the error should be reported to an error tracker
+// the outermost MarkReportable or MarkNotReportable of the chain wins, otherwise only server errors (5xx) and unknown errors are reported.
+// It returns false for nil errors
+func ShouldReport(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, err := range chain(err) {
+		if r, ok := err.(reportableError); ok {
+			return r.report
+		}
+	}
+	return statusCode(err) >= 500
+}
+
+// reportableError is the wrapper returned by MarkReportable and MarkNotReportable
+type reportableError struct {
+	err    error
+	report bool
+}
+
+// Error returns the string representation of the wrapped error
+func (e reportableError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e reportableError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e reportableError) Unwrap() error {
+	return e.err
+}
+
+// WithErrorID annotates the error with a random short ID, which is included in the responses of server errors
+// so that users can quote it to support. If the error already has an ID, it's returned unchanged. It returns nil for nil errors
+func WithErrorID(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := ErrorID(err); ok {
+		return err
+	}
+	b := make([]byte, 4)
+	rand.Read(b)
+	return withErrorID{err: err, id: hex.EncodeToString(b)}
+}
+
+// ErrorID returns the ID of the outermost WithErrorID of the chain
+func ErrorID(err error) (string, bool) {
+	for _, err := range chain(err) {
+		if e, ok := err.(withErrorID); ok {
+			return e.id, true
+		}
+	}
+	return "", false
+}
+
+// withErrorID is the wrapper returned by WithErrorID
+type withErrorID struct {
+	err error
+	id  string
+}
+
+// Error returns the string representation of the wrapped error
+func (e withErrorID) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withErrorID) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withErrorID) Unwrap() error {
+	return e.err
+}
+
+// WithTimestamp annotates the error with the current time, e.g. to measure how long an error propagated until it's logged
+// the classification of the error is preserved. It returns nil for nil errors
+func WithTimestamp(err error) error {
+	if err == nil {
+		return nil
+	}
+	return withTimestamp{err: err, t: time.Now()}
+}
+
+// Timestamp returns the time recorded by the innermost WithTimestamp of the chain, which is the earliest one
+func Timestamp(err error) (time.Time, bool) {
+	var t time.Time
+	var found bool
+	for _, err := range chain(err) {
+		if w, ok := err.(withTimestamp); ok {
+			t, found = w.t, true
+		}
+	}
+	return t, found
+}
+
+// withTimestamp is the wrapper returned by WithTimestamp
+type withTimestamp struct {
+	err error
+	t   time.Time
+}
+
+// Error returns the string representation of the wrapped error
+func (e withTimestamp) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withTimestamp) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withTimestamp) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,27 @@
+package errtypes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttributesSeverity(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"client error", NewNotFound("not found"), "warning"},
+		{"server error", NewInternal("internal"), "error"},
+		{"unknown error", errors.New("unknown"), "error"},
+		{"reportable client error", MarkReportable(NewBadInput("bad input")), "error"},
+		{"not reportable server error", MarkNotReportable(NewServiceUnavailable("maintenance")), "warning"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Attributes(tt.err)["severity"]; got != tt.want {
+				t.Errorf("Attributes()[\"severity\"] = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package errtypes
+
+import (
+	"context"
+	"net/http"
+)
+
+// HandlerFunc is an HTTP handler, which returns its error instead of writing it to the response
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// LogFunc logs an error together with the HTTP status code it's responded with
+type LogFunc func(ctx context.Context, err error, status int)
+
+// Middleware returns a middleware, which turns a HandlerFunc into an http.Handler
+// errors returned by the handler are logged with log, unless they were already logged, and then written with WriteHTTPError
+func Middleware(log LogFunc) func(HandlerFunc) http.Handler {
+	return func(h HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := h(w, r)
+			if err == nil {
+				return
+			}
+			if log != nil && !WasLogged(err) {
+				log(r.Context(), err, statusCode(err))
+			}
+			WriteHTTPError(w, err)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Package multierr converts errors of github.com/hashicorp/go-multierror into the types of errtypes
+package multierr
+
+import (
+	"github.com/fvosberg/errtypes"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// FromMultierr unpacks a *multierror.Error and combines its errors with errtypes.Combine
+// other errors are returned unchanged
+func FromMultierr(err error) error {
+	var merr *multierror.Error
+	if !errors.As(err, &merr) {
+		return err
+	}
+	return errtypes.Combine(merr.Errors...)
+}
@@ -0,0 +1,58 @@
+package errtypes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NegotiationError returns a not acceptable error for a client, which accepts none of the supported media types
+// the requested and supported media types are available as fields and the supported ones are included in the responses
+func NegotiationError(requested, supported []string) error {
+	return negotiationError{
+		err:       NewNotAcceptablef("none of the accepted media types is supported, supported are: %s", strings.Join(supported, ", ")),
+		requested: requested,
+		supported: supported,
+	}
+}
+
+// ContentTypeError returns an unsupported media type error for a request body with a media type, which isn't supported
+// the requested and supported media types are available as fields and the supported ones are included in the responses
+func ContentTypeError(requested string, supported []string) error {
+	return negotiationError{
+		err:       NewUnsupportedMediaType(fmt.Sprintf("media type %s is not supported, supported are: %s", requested, strings.Join(supported, ", "))),
+		requested: []string{requested},
+		supported: supported,
+	}
+}
+
+// negotiationError is the error returned by NegotiationError and ContentTypeError
+type negotiationError struct {
+	err       error
+	requested []string
+	supported []string
+}
+
+// Error returns the string representation of the wrapped error
+func (e negotiationError) Error() string {
+	return e.err.Error()
+}
+
+// Supported returns the media types supported by the server
+func (e negotiationError) Supported() []string {
+	return e.supported
+}
+
+// Fields returns the requested and supported media types
+func (e negotiationError) Fields() map[string]interface{} {
+	return map[string]interface{}{"requested": e.requested, "supported": e.supported}
+}
+
+// Cause returns the wrapped error
+func (e negotiationError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e negotiationError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,26 @@
+package errtypes
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// FromNetError classifies network errors of clients, wrapping the original error
+// timeouts become Timeout errors, other *net.OpError and *net.DNSError errors, e.g. a refused connection,
+// become ServiceUnavailable errors. Other errors and errors, which are already classified, are returned unchanged
+func FromNetError(err error) error {
+	if IsClassified(err) {
+		return err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return WrapTimeout(err, "")
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return WrapServiceUnavailable(err, "")
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+// Package pgerr converts errors of PostgreSQL via github.com/jackc/pgx into the types of errtypes
+// only the *pgconn.PgError of pgx v5 is supported, errors of github.com/lib/pq are returned unchanged
+package pgerr
+
+import (
+	"github.com/fvosberg/errtypes"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// From returns a conflict error for unique violations and a bad input error for foreign key violations
+// the name of the violated constraint is attached as field "constraint". Other errors are returned unchanged
+func From(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case uniqueViolation:
+		return errtypes.WithField(errtypes.WrapConflict(err, "already exists"), "constraint", pgErr.ConstraintName)
+	case foreignKeyViolation:
+		return errtypes.WithField(errtypes.WrapBadInput(err, "invalid reference"), "constraint", pgErr.ConstraintName)
+	default:
+		return err
+	}
+}
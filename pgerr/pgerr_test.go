@@ -0,0 +1,41 @@
+package pgerr
+
+import (
+	"testing"
+
+	"github.com/fvosberg/errtypes"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		constraint interface{}
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}, 409, "users_email_key"},
+		{"foreign key violation", &pgconn.PgError{Code: "23503", ConstraintName: "orders_user_id_fkey"}, 400, "orders_user_id_fkey"},
+		{"wrapped unique violation", errors.Wrap(&pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}, "insert user"), 409, "users_email_key"},
+		{"other code", &pgconn.PgError{Code: "42P01"}, 500, nil},
+		{"no postgres error", errors.New("connection reset"), 500, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := From(tt.err)
+			if got := errtypes.HTTPStatusCode(err); got != tt.status {
+				t.Errorf("HTTPStatusCode() = %d, want %d", got, tt.status)
+			}
+			if got := errtypes.Fields(err)["constraint"]; got != tt.constraint {
+				t.Errorf("constraint = %v, want %v", got, tt.constraint)
+			}
+		})
+	}
+}
+
+func TestFromNil(t *testing.T) {
+	if err := From(nil); err != nil {
+		t.Errorf("From(nil) = %v, want nil", err)
+	}
+}
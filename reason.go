@@ -0,0 +1,58 @@
+package errtypes
+
+// Reason refines the kind of an error for clients, which handle e.g. different forbidden errors differently
+type Reason string
+
+const (
+	// ReasonPermission is used for forbidden errors, which are caused by insufficient permissions
+	ReasonPermission Reason = "permission"
+	// ReasonPlanLimit is used for forbidden errors, which are caused by a limit of the plan
+	ReasonPlanLimit Reason = "plan_limit"
+
+	// ReasonMissing is used for bad input errors, which are caused by a missing required parameter
+	ReasonMissing Reason = "missing"
+	// ReasonInvalid is used for bad input errors, which are caused by a malformed parameter,
+	// and for unauthenticated errors, which are caused by malformed or forged credentials
+	ReasonInvalid Reason = "invalid"
+	// ReasonOutOfRange is used for bad input errors, which are caused by a parameter out of the allowed range
+	ReasonOutOfRange Reason = "out_of_range"
+
+	// ReasonExpired is used for unauthenticated errors, which are caused by expired credentials
+	ReasonExpired Reason = "expired"
+)
+
+// ForbiddenReason returns the reason of a forbidden error
+// forbidden errors without a reason return ReasonPermission, other errors an empty reason
+func ForbiddenReason(err error) Reason {
+	if !IsForbidden(err) {
+		return ""
+	}
+	if r, ok := cause(err).(interface{ Reason() Reason }); ok {
+		return r.Reason()
+	}
+	return ReasonPermission
+}
+
+// BadInputReason returns the reason of a bad input error
+// bad input errors without a reason return ReasonInvalid, other errors an empty reason
+func BadInputReason(err error) Reason {
+	if !IsBadInput(err) {
+		return ""
+	}
+	if r, ok := cause(err).(interface{ Reason() Reason }); ok {
+		return r.Reason()
+	}
+	return ReasonInvalid
+}
+
+// UnauthenticatedReason returns the reason of an unauthenticated error
+// unauthenticated errors without a reason and other errors return an empty reason
+func UnauthenticatedReason(err error) Reason {
+	if !IsUnauthenticated(err) {
+		return ""
+	}
+	if r, ok := cause(err).(interface{ Reason() Reason }); ok {
+		return r.Reason()
+	}
+	return ""
+}
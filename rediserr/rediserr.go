@@ -0,0 +1,17 @@
+// Package rediserr converts errors of github.com/redis/go-redis into the types of errtypes
+package rediserr
+
+import (
+	"github.com/fvosberg/errtypes"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// FromRedis returns a not found error for cache misses (redis.Nil), wrapping the original error
+// other errors are returned unchanged
+func FromRedis(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return errtypes.WrapNotFound(err, "cache miss")
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+package errtypes
+
+import (
+	"net"
+	"time"
+)
+
+// Retryable is used for errors, which are temporary, so that the request can be retried later
+type Retryable interface {
+	IsRetryable() bool
+}
+
+// IsRetryable checks, whether the request which caused this error can be retried later
+// it returns false for nil errors
+func IsRetryable(err error) bool {
+	v, ok := cause(err).(Retryable)
+	return ok && v.IsRetryable()
+}
+
+// IsTransient checks, whether the error indicates that the service is unhealthy, rather than the request being bad
+// this is the case for ServiceUnavailable, GatewayTimeout and Timeout errors. It's independent from IsRetryable
+func IsTransient(err error) bool {
+	return IsServiceUnavailable(err) || IsGatewayTimeout(err) || IsTimeout(err)
+}
+
+// DefaultRetryAfter is the backoff suggested by RetryAfterFor for retryable errors without a Retry-After
+var DefaultRetryAfter = time.Second
+
+// WithRetryAfter annotates the error with the duration after which the request can be retried
+// the classification of the error is preserved. It returns nil for nil errors
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return withRetryAfter{err: err, d: d}
+}
+
+// RetryAfter returns the Retry-After of the outermost error of the chain, which implements RetryAfter() time.Duration
+// for combined errors it's the soonest Retry-After of the combined errors
+func RetryAfter(err error) (time.Duration, bool) {
+	for _, err := range chain(err) {
+		if r, ok := err.(interface{ RetryAfter() time.Duration }); ok {
+			return r.RetryAfter(), true
+		}
+		if c, ok := err.(*combinedError); ok {
+			return c.retryAfter()
+		}
+	}
+	return 0, false
+}
+
+// RetryAfterFor returns the suggested backoff before retrying the request, which caused the error
+// this is the Retry-After of the error, or DefaultRetryAfter if there is none. For errors, which aren't retryable, it returns false
+func RetryAfterFor(err error) (time.Duration, bool) {
+	if !IsRetryable(err) {
+		return 0, false
+	}
+	if d, ok := RetryAfter(err); ok {
+		return d, true
+	}
+	return DefaultRetryAfter, true
+}
+
+// withRetryAfter is the wrapper returned by WithRetryAfter
+type withRetryAfter struct {
+	err error
+	d   time.Duration
+}
+
+// Error returns the string representation of the wrapped error
+func (e withRetryAfter) Error() string {
+	return e.err.Error()
+}
+
+// RetryAfter returns the duration after which the request can be retried
+func (e withRetryAfter) RetryAfter() time.Duration {
+	return e.d
+}
+
+// Cause returns the wrapped error
+func (e withRetryAfter) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withRetryAfter) Unwrap() error {
+	return e.err
+}
+
+// IsIdempotentSafe checks, whether repeating the request, which caused the error, can't apply it twice
+// this is the case, if the request has been rejected before it was processed: BadInput, Unauthenticated, Forbidden,
+// NotFound and TooManyRequests errors, and ServiceUnavailable errors, which aren't caused by a timeout or a network error
+// after the connection has been established. For all other kinds, e.g. conflicts and timeouts, it returns false.
+// The default can be overridden with WithIdempotentSafe
+func IsIdempotentSafe(err error) bool {
+	for _, err := range chain(err) {
+		if i, ok := err.(withIdempotentSafe); ok {
+			return i.safe
+		}
+	}
+	switch KindOf(err) {
+	case KindBadInput, KindUnauthenticated, KindForbidden, KindNotFound, KindTooManyRequests:
+		return true
+	case KindServiceUnavailable:
+		return !mayHaveBeenSent(err)
+	default:
+		return false
+	}
+}
+
+// mayHaveBeenSent checks, whether the chain contains a timeout or a network error, which may have occurred after the request has been sent
+// failed dials and DNS lookups happen before, so they don't count
+func mayHaveBeenSent(err error) bool {
+	for _, err := range chain(err) {
+		if k := layerKind(err); k == KindTimeout || k == KindGatewayTimeout {
+			return true
+		}
+		switch e := err.(type) {
+		case *net.DNSError:
+			return false
+		case *net.OpError:
+			return e.Op != "dial"
+		case net.Error:
+			return true
+		}
+	}
+	return false
+}
+
+// WithIdempotentSafe overrides the result of IsIdempotentSafe for the error
+// the classification of the error is preserved. It returns nil for nil errors
+func WithIdempotentSafe(err error, safe bool) error {
+	if err == nil {
+		return nil
+	}
+	return withIdempotentSafe{err: err, safe: safe}
+}
+
+// withIdempotentSafe is the wrapper returned by WithIdempotentSafe
+type withIdempotentSafe struct {
+	err  error
+	safe bool
+}
+
+// Error returns the string representation of the wrapped error
+func (e withIdempotentSafe) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withIdempotentSafe) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withIdempotentSafe) Unwrap() error {
+	return e.err
+}
+
+// CountsAsFailure checks, whether the error should count as a failure of the called service, e.g. for a circuit breaker
+// this is the case for server errors (5xx) and retryable errors, but not for other client errors (4xx). It returns false for nil errors.
+// The default can be overridden with WithCountsAsFailure
+func CountsAsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, err := range chain(err) {
+		if f, ok := err.(withCountsAsFailure); ok {
+			return f.failure
+		}
+	}
+	return statusCode(err) >= 500 || IsRetryable(err)
+}
+
+// WithCountsAsFailure overrides the result of CountsAsFailure for the error
+// the classification of the error is preserved. It returns nil for nil errors
+func WithCountsAsFailure(err error, failure bool) error {
+	if err == nil {
+		return nil
+	}
+	return withCountsAsFailure{err: err, failure: failure}
+}
+
+// withCountsAsFailure is the wrapper returned by WithCountsAsFailure
+type withCountsAsFailure struct {
+	err     error
+	failure bool
+}
+
+// Error returns the string representation of the wrapped error
+func (e withCountsAsFailure) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withCountsAsFailure) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withCountsAsFailure) Unwrap() error {
+	return e.err
+}
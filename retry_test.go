@@ -0,0 +1,41 @@
+package errtypes
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+)
+
+func TestIsIdempotentSafe(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"bad input", NewBadInput("bad input"), true},
+		{"too many requests", NewTooManyRequests("slow down"), true},
+		{"conflict", NewConflict("conflict"), false},
+		{"timeout", NewTimeout("timeout"), false},
+		{"service unavailable", NewServiceUnavailable("maintenance"), true},
+		{"refused connection", FromNetError(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"failed DNS lookup", FromNetError(&net.DNSError{Err: "no such host", Name: "api"}), true},
+		{"reset connection", FromNetError(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), false},
+		{"exhausted retries of timeouts", NewRetryExhausted(3, NewTimeout("timeout")), false},
+		{"exhausted retries of unavailable service", NewRetryExhausted(3, NewServiceUnavailable("maintenance")), true},
+		{"override", WithIdempotentSafe(NewConflict("conflict"), true), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIdempotentSafe(tt.err); got != tt.want {
+				t.Errorf("IsIdempotentSafe() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIdempotentSafeUnknown(t *testing.T) {
+	if IsIdempotentSafe(errors.New("unknown")) {
+		t.Error("IsIdempotentSafe() = true for an unknown error")
+	}
+}
@@ -0,0 +1,76 @@
+package errtypes
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// CaptureStacks controls, whether WithStack records stack traces
+// it can be disabled in high throughput services to trade debuggability for performance
+var CaptureStacks = true
+
+// WithStack annotates the error with the stack trace at the point it was called, printed with %+v
+// the classification of the error is preserved. If CaptureStacks is disabled, the error is returned unchanged
+func WithStack(err error) error {
+	if !CaptureStacks {
+		return err
+	}
+	return errors.WithStack(err)
+}
+
+// NewInternalHere returns an internal error, which records the file and line, where it has been created
+// it's a cheaper alternative to a stack trace, the location is returned by Caller
+func NewInternalHere(s string) error {
+	return withCaller(NewInternal(s), 2)
+}
+
+// WithCaller annotates the error with the file and line, where WithCaller has been called, returned by Caller
+// the classification of the error is preserved. It returns nil for nil errors
+func WithCaller(err error) error {
+	if err == nil {
+		return nil
+	}
+	return withCaller(err, 2)
+}
+
+// withCaller annotates the error with the location of the caller, skip is passed to runtime.Caller
+func withCaller(err error, skip int) error {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return err
+	}
+	return callerError{err: err, caller: fmt.Sprintf("%s:%d", file, line)}
+}
+
+// Caller returns the file and line recorded by the outermost WithCaller or NewInternalHere of the chain, e.g. "/src/app/user.go:42"
+func Caller(err error) (string, bool) {
+	for _, err := range chain(err) {
+		if c, ok := err.(callerError); ok {
+			return c.caller, true
+		}
+	}
+	return "", false
+}
+
+// callerError is the wrapper returned by WithCaller
+type callerError struct {
+	err    error
+	caller string
+}
+
+// Error returns the string representation of the wrapped error
+func (e callerError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e callerError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e callerError) Unwrap() error {
+	return e.err
+}
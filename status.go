@@ -0,0 +1,74 @@
+package errtypes
+
+// WithStatus overrides the HTTP status code of the error, it takes precedence over all other sources of HTTPStatusCode
+// the classification of the error is preserved. It returns nil for nil errors
+func WithStatus(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return withStatus{err: err, code: code}
+}
+
+// statusOverride returns the status code of the outermost WithStatus of the Cause chain, the same path cause follows
+// so that overrides of errors, which have been reclassified, e.g. by Wrap* or MaskForbiddenAsNotFound, don't apply.
+// It walks the chain without collecting it, because it's called for every HTTPStatusCode
+func statusOverride(err error) (int, bool) {
+	for i := 0; err != nil && i < MaxChainDepth; i++ {
+		if s, ok := err.(withStatus); ok {
+			return s.code, true
+		}
+		c, ok := err.(interface{ Cause() error })
+		if !ok {
+			return 0, false
+		}
+		err = c.Cause()
+	}
+	return 0, false
+}
+
+// withStatus is the wrapper returned by WithStatus
+type withStatus struct {
+	err  error
+	code int
+}
+
+// Error returns the string representation of the wrapped error
+func (e withStatus) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e withStatus) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e withStatus) Unwrap() error {
+	return e.err
+}
+
+// statusMatcher is a matcher registered with RegisterStatus
+type statusMatcher struct {
+	match  func(error) bool
+	status int
+}
+
+// statusRegistry contains the matchers registered with RegisterStatus
+var statusRegistry []statusMatcher
+
+// RegisterStatus registers a status code for errors of third party libraries, which match the predicate
+// it's only used by HTTPStatusCode, if the error doesn't match any of the types of this package.
+// The matchers are checked in the order of their registration. It's meant to be called during initialization
+func RegisterStatus(match func(error) bool, status int) {
+	statusRegistry = append(statusRegistry, statusMatcher{match: match, status: status})
+}
+
+// registeredStatus returns the status code of the first registered matcher, which matches the error
+func registeredStatus(err error) (int, bool) {
+	for _, m := range statusRegistry {
+		if m.match(err) {
+			return m.status, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,63 @@
+package errtypes
+
+import (
+	"errors"
+	"testing"
+)
+
+type customStatusError struct{}
+
+func (customStatusError) Error() string   { return "custom" }
+func (customStatusError) HTTPStatus() int { return 451 }
+
+func TestHTTPStatusCodePrecedence(t *testing.T) {
+	RegisterStatus(func(err error) bool { return err.Error() == "registered" }, 418)
+	defer func() { statusRegistry = nil }()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"override wins over the type", WithStatus(NewNotFound("x"), 410), 410},
+		{"override wins over custom status", WithStatus(customStatusError{}, 410), 410},
+		{"outermost override wins", WithStatus(WithStatus(NewNotFound("x"), 410), 418), 418},
+		{"override below metadata", WithCode(WithStatus(NewNotFound("x"), 410), "gone"), 410},
+		{"custom status wins over registered", customStatusError{}, 451},
+		{"type", NewConflict("x"), 409},
+		{"registered", errors.New("registered"), 418},
+		{"unknown", errors.New("unknown"), UnknownStatus},
+		{"override of a wrapped cause", WrapNotFound(WithStatus(errors.New("x"), 418), ""), 404},
+		{"override of a reclassified error", Reclassify(WithStatus(errors.New("x"), 418), KindBadInput, ""), 400},
+		{"override of a masked forbidden error", MaskForbiddenAsNotFound(WithStatus(NewForbidden("secret"), 403)), 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatusCode(tt.err); got != tt.want {
+				t.Errorf("HTTPStatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictUnknownOnlyReportsResponses(t *testing.T) {
+	var reported int
+	StrictUnknown, UnknownHook = true, func(error) { reported++ }
+	defer func() { StrictUnknown, UnknownHook = false, nil }()
+
+	err := errors.New("unknown")
+	ShouldReport(err)
+	CountsAsFailure(err)
+	Attributes(err)
+	ErrorDetails(err)
+	MostSevere(err, NewNotFound("not found"))
+	ReasonPhrase(err)
+	if reported != 0 {
+		t.Errorf("the classifying helpers reported %d times, want 0", reported)
+	}
+
+	Respond(err)
+	if reported != 1 {
+		t.Errorf("Respond() reported %d times, want 1", reported)
+	}
+}
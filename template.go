@@ -0,0 +1,32 @@
+package errtypes
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// TemplateData is the data passed to the templates by RenderTemplate
+type TemplateData struct {
+	Status       int
+	Kind         Kind
+	ReasonPhrase string
+	// Message is the message of the error, which can be shown to the user - for server errors it's the reason phrase
+	Message string
+}
+
+// RenderTemplate renders an error page for the error with the template, e.g. for server rendered pages
+// Like HTTPStatusCode it panics for nil values
+func RenderTemplate(err error, tmpl *template.Template) ([]byte, error) {
+	status := HTTPStatusCode(err)
+	data := TemplateData{
+		Status:       status,
+		Kind:         KindOf(err),
+		ReasonPhrase: statusText(status),
+		Message:      newErrorResponse(err, status).Error,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
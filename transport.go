@@ -0,0 +1,61 @@
+package errtypes
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// encodedError is the JSON representation used by Encode and Decode
+type encodedError struct {
+	Kind        Kind                   `json:"kind"`
+	Message     string                 `json:"message"`
+	Code        string                 `json:"code,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	FieldErrors map[string]string      `json:"field_errors,omitempty"`
+}
+
+// Encode serializes the kind, message, code and fields of the error to JSON, e.g. to pass it to another service
+// the invalid fields of validation errors are included. Nil errors are encoded as null
+func Encode(err error) []byte {
+	if err == nil {
+		return []byte("null")
+	}
+	e := encodedError{
+		Kind:    KindOf(err),
+		Message: err.Error(),
+		Code:    Code(err),
+		Fields:  Fields(err),
+	}
+	if v := validationError(err); v != nil && e.Kind == KindBadInput {
+		e.FieldErrors = v.fields
+	}
+	b, _ := json.Marshal(e)
+	return b
+}
+
+// Decode reconstructs an error with the same kind, message, code and fields from the output of Encode
+// validation errors are reconstructed with their invalid fields
+// it returns nil for null and an unclassified error, if the data can't be decoded
+func Decode(data []byte) error {
+	var e *encodedError
+	if err := json.Unmarshal(data, &e); err != nil {
+		return errors.Wrap(err, "decoding error failed")
+	}
+	if e == nil {
+		return nil
+	}
+	var err error
+	if e.Kind == KindBadInput && len(e.FieldErrors) > 0 {
+		err = NewValidationErrors(e.FieldErrors)
+	} else {
+		err = New(e.Kind, e.Message)
+	}
+	if e.Code != "" {
+		err = WithCode(err, e.Code)
+	}
+	if len(e.Fields) > 0 {
+		err = withFields{err: err, fields: e.Fields}
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package errtypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"classified", WithCode(NewNotFound("user not found"), "user_missing")},
+		{"fields", WithField(NewConflict("duplicate"), "id", "42")},
+		{"validation", NewValidationErrors(map[string]string{"name": "is required", "age": "must be positive"})},
+		{"unknown", New(KindUnknown, "unknown")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Decode(Encode(tt.err))
+			if !Equal(got, tt.err) {
+				t.Errorf("Decode(Encode()) = %v, want %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeValidationFields(t *testing.T) {
+	fields := map[string]string{"name": "is required", "items[2].price": "must be positive"}
+	got := Decode(Encode(WithCode(NewValidationErrors(fields), "invalid_order")))
+	v := validationError(got)
+	if v == nil {
+		t.Fatalf("Decode() = %T, want a ValidationError", got)
+	}
+	if !reflect.DeepEqual(v.FieldErrors(), fields) {
+		t.Errorf("FieldErrors() = %v, want %v", v.FieldErrors(), fields)
+	}
+	if Code(got) != "invalid_order" {
+		t.Errorf("Code() = %q, want %q", Code(got), "invalid_order")
+	}
+}
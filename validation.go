@@ -0,0 +1,121 @@
+package errtypes
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// ValidationError is a BadInput error, which lists the invalid fields with their messages
+// The corresponding HTTP status code is 400
+type ValidationError struct {
+	fields map[string]string
+}
+
+// NewValidationErrors returns a validation error for the fields, mapped to their messages
+func NewValidationErrors(fieldErrs map[string]string) error {
+	fields := make(map[string]string, len(fieldErrs))
+	for f, msg := range fieldErrs {
+		fields[f] = msg
+	}
+	return &ValidationError{fields: fields}
+}
+
+// Error returns the string representation of this error, listing the fields in alphabetical order
+func (e *ValidationError) Error() string {
+	errs := e.SortedFieldErrors()
+	parts := make([]string, len(errs))
+	for i, fe := range errs {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return "invalid input: " + strings.Join(parts, ", ")
+}
+
+// IsBadInput indicates, whether this error is caused by a missing or wrong input parameter, or not
+func (e *ValidationError) IsBadInput() bool {
+	return true
+}
+
+// Temporary indicates, that this error isn't temporary
+func (e *ValidationError) Temporary() bool {
+	return false
+}
+
+// FieldErrors returns the paths of the invalid fields mapped to their messages
+// nested fields have paths like "items[2].price"
+func (e *ValidationError) FieldErrors() map[string]string {
+	fields := make(map[string]string, len(e.fields))
+	for f, msg := range e.fields {
+		fields[f] = msg
+	}
+	return fields
+}
+
+// FieldError is the message of a single invalid field of a ValidationError
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// SortedFieldErrors returns the invalid fields with their messages, sorted by the paths of the fields
+func (e *ValidationError) SortedFieldErrors() []FieldError {
+	errs := make([]FieldError, 0, len(e.fields))
+	for f, msg := range e.fields {
+		errs = append(errs, FieldError{Field: f, Message: msg})
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+	return errs
+}
+
+// AddNested merges the field errors of a validation of a nested object or array into this error
+// the paths of the inner fields are prefixed, e.g. "price" with the prefix "items[2]" becomes "items[2].price"
+func (e *ValidationError) AddNested(prefix string, inner *ValidationError) {
+	if inner == nil {
+		return
+	}
+	if e.fields == nil {
+		e.fields = make(map[string]string, len(inner.fields))
+	}
+	for f, msg := range inner.fields {
+		e.fields[joinPath(prefix, f)] = msg
+	}
+}
+
+// joinPath joins the prefix and the path of a field with a dot, unless the path starts with an index
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// MarshalJSON returns the JSON representation of this error, the fields are sorted by their paths, e.g. {"error": "...", "status": 400, "fields": {"name": "is required"}}
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorResponse{Error: e.Error(), Status: 400, Fields: e.fields})
+}
+
+// FieldErrorMessage returns the message of the invalid field of the outermost ValidationError of the chain
+// it returns false, if there is no ValidationError or the field is valid
+func FieldErrorMessage(err error, field string) (string, bool) {
+	v := validationError(err)
+	if v == nil {
+		return "", false
+	}
+	msg, ok := v.fields[field]
+	return msg, ok
+}
+
+// validationError returns the outermost ValidationError of the chain, nil if there is none
+func validationError(err error) *ValidationError {
+	for _, err := range chain(err) {
+		if v, ok := err.(*ValidationError); ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package validatorerr converts errors of github.com/go-playground/validator into the types of errtypes
+package validatorerr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fvosberg/errtypes"
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// From converts validator.ValidationErrors into an errtypes.ValidationError with a message per field
+// the fields are keyed by their path without the name of the validated struct, e.g. "address.street" or "items[2].price".
+// Other errors are returned unchanged
+func From(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	fields := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		fields[path(fe)] = message(fe)
+	}
+	return errtypes.NewValidationErrors(fields)
+}
+
+// path returns the namespace of the field without the name of the validated struct
+func path(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+// message returns a human readable message for the failed tag of the field
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "len":
+		return fmt.Sprintf("must have a length of %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", fe.Param())
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed on the '%s=%s' validation", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed on the '%s' validation", fe.Tag())
+}
@@ -0,0 +1,45 @@
+package validatorerr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fvosberg/errtypes"
+	"github.com/go-playground/validator/v10"
+)
+
+type address struct {
+	Name string `validate:"required"`
+}
+
+type item struct {
+	Name  string `validate:"required"`
+	Price int    `validate:"min=1"`
+}
+
+type order struct {
+	Name     string  `validate:"required"`
+	Shipping address `validate:"required"`
+	Billing  address `validate:"required"`
+	Items    []item  `validate:"dive"`
+}
+
+func TestFromNested(t *testing.T) {
+	err := From(validator.New().Struct(order{
+		Shipping: address{Name: "Jane"},
+		Items:    []item{{Name: "book", Price: 1}, {Price: 0}},
+	}))
+	verr, ok := err.(*errtypes.ValidationError)
+	if !ok {
+		t.Fatalf("From() = %T, want a *errtypes.ValidationError", err)
+	}
+	want := map[string]string{
+		"Name":           "is required",
+		"Billing.Name":   "is required",
+		"Items[1].Name":  "is required",
+		"Items[1].Price": "must be at least 1",
+	}
+	if got := verr.FieldErrors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldErrors() = %v, want %v", got, want)
+	}
+}